@@ -0,0 +1,281 @@
+// Package chart renders bar and line charts as SVG that can be embedded directly in HTML or served as an image.
+package chart
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default chart dimensions in pixels.
+const (
+	DefaultWidth  = 480
+	DefaultHeight = 200
+)
+
+// Chart colors match the site stylesheet.
+const (
+	dataColor  = "#006992"
+	textColor  = "#7d786c"
+	gridColor  = "#d8d1c0"
+	fontSize   = 10
+	minXLabelW = 28 // minimum horizontal space in pixels for an x axis label
+)
+
+// Point is a labeled value on a chart.
+type Point struct {
+	Label string
+	Value float64
+}
+
+// BarChart renders a bar for each point.
+type BarChart struct {
+	Title  string // accessible description of the chart
+	Width  int    // DefaultWidth if 0
+	Height int    // DefaultHeight if 0
+	Points []Point
+}
+
+// WriteSVG writes c as an SVG document.
+func (c *BarChart) WriteSVG(w io.Writer) error {
+	p := newPlot(c.Title, c.Width, c.Height, c.Points)
+
+	sb := &strings.Builder{}
+	p.writeStart(sb)
+	for i, pt := range c.Points {
+		barWidth := p.slotWidth() * 0.7
+		x := p.slotX(i) - barWidth/2
+		y := p.y(pt.Value)
+		fmt.Fprintf(sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s</title></rect>`,
+			x, y, barWidth, p.bottom-y, dataColor, pointTitle(pt))
+	}
+	p.writeEnd(sb)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// LineChart renders a line connecting the points.
+type LineChart struct {
+	Title  string // accessible description of the chart
+	Width  int    // DefaultWidth if 0
+	Height int    // DefaultHeight if 0
+	Points []Point
+}
+
+// WriteSVG writes c as an SVG document.
+func (c *LineChart) WriteSVG(w io.Writer) error {
+	p := newPlot(c.Title, c.Width, c.Height, c.Points)
+
+	sb := &strings.Builder{}
+	p.writeStart(sb)
+	if len(c.Points) > 0 {
+		coords := make([]string, 0, len(c.Points))
+		for i, pt := range c.Points {
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", p.slotX(i), p.y(pt.Value)))
+		}
+		fmt.Fprintf(sb, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(coords, " "), dataColor)
+
+		for i, pt := range c.Points {
+			fmt.Fprintf(sb, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s</title></circle>`,
+				p.slotX(i), p.y(pt.Value), dataColor, pointTitle(pt))
+		}
+	}
+	p.writeEnd(sb)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// plot is the layout shared by all chart types. Points are placed in equal width slots along the x axis.
+type plot struct {
+	title         string
+	width, height int
+	left, right   float64
+	top, bottom   float64
+	max           float64
+	points        []Point
+}
+
+func newPlot(title string, width, height int, points []Point) *plot {
+	if width == 0 {
+		width = DefaultWidth
+	}
+	if height == 0 {
+		height = DefaultHeight
+	}
+
+	var max float64
+	for _, pt := range points {
+		max = math.Max(max, pt.Value)
+	}
+
+	return &plot{
+		title:  title,
+		width:  width,
+		height: height,
+		left:   32,
+		right:  float64(width) - 8,
+		top:    8,
+		bottom: float64(height) - 2*fontSize - 4,
+		max:    niceMax(max),
+		points: points,
+	}
+}
+
+func (p *plot) slotWidth() float64 {
+	if len(p.points) == 0 {
+		return p.right - p.left
+	}
+	return (p.right - p.left) / float64(len(p.points))
+}
+
+// slotX returns the x coordinate of the center of slot i.
+func (p *plot) slotX(i int) float64 {
+	return p.left + p.slotWidth()*(float64(i)+0.5)
+}
+
+func (p *plot) y(value float64) float64 {
+	return p.bottom - value/p.max*(p.bottom-p.top)
+}
+
+// writeStart writes the opening svg tag, the y axis grid, and the x axis labels.
+func (p *plot) writeStart(sb *strings.Builder) {
+	fmt.Fprintf(sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" font-family="sans-serif" font-size="%d">`,
+		p.width, p.height, p.width, p.height, fontSize)
+	if p.title != "" {
+		fmt.Fprintf(sb, `<title>%s</title>`, html.EscapeString(p.title))
+	}
+
+	for _, v := range []float64{0, p.max / 2, p.max} {
+		y := p.y(v)
+		fmt.Fprintf(sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`, p.left, y, p.right, y, gridColor)
+		fmt.Fprintf(sb, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`,
+			p.left-4, y, textColor, formatValue(v))
+	}
+
+	// Skip labels when there is not enough room to show them all.
+	labelEvery := int(math.Ceil(minXLabelW / p.slotWidth()))
+	for i, pt := range p.points {
+		if i%labelEvery != 0 {
+			continue
+		}
+		fmt.Fprintf(sb, `<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s">%s</text>`,
+			p.slotX(i), p.bottom+fontSize+4, textColor, html.EscapeString(pt.Label))
+	}
+}
+
+func (p *plot) writeEnd(sb *strings.Builder) {
+	sb.WriteString(`</svg>`)
+}
+
+// niceMax rounds max up to 1, 2, or 5 times a power of 10 so the axis labels are round numbers.
+func niceMax(max float64) float64 {
+	if max <= 0 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(max)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if step*magnitude >= max {
+			return step * magnitude
+		}
+	}
+
+	return 10 * magnitude
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func pointTitle(pt Point) string {
+	return html.EscapeString(pt.Label + ": " + formatValue(pt.Value))
+}
+
+// Day is a value for a calendar day.
+type Day struct {
+	Date  time.Time
+	Value float64
+}
+
+// Heatmap renders consecutive days as a calendar grid with a column per week and a row per weekday. Darker cells have
+// higher values.
+type Heatmap struct {
+	Title string // accessible description of the chart
+	Days  []Day  // consecutive days in ascending order
+}
+
+// Heatmap layout in pixels.
+const (
+	heatmapCellSize = 10
+	heatmapCellGap  = 2
+	heatmapLeft     = 24
+	heatmapTop      = fontSize + 6
+)
+
+// heatmapOpacities are the fill opacities for values from lowest to highest. Zero values use the grid color.
+var heatmapOpacities = []float64{0.3, 0.55, 0.8, 1}
+
+// WriteSVG writes h as an SVG document.
+func (h *Heatmap) WriteSVG(w io.Writer) error {
+	cellStep := heatmapCellSize + heatmapCellGap
+
+	var max float64
+	weeks := 0
+	if len(h.Days) > 0 {
+		start := h.Days[0].Date
+		weeks = (int(start.Weekday())+len(h.Days)-1)/7 + 1
+		for _, d := range h.Days {
+			max = math.Max(max, d.Value)
+		}
+	}
+
+	width := heatmapLeft + weeks*cellStep
+	height := heatmapTop + 7*cellStep
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" font-family="sans-serif" font-size="%d">`,
+		width, height, width, height, fontSize)
+	if h.Title != "" {
+		fmt.Fprintf(sb, `<title>%s</title>`, html.EscapeString(h.Title))
+	}
+
+	for _, wd := range []time.Weekday{time.Monday, time.Wednesday, time.Friday} {
+		fmt.Fprintf(sb, `<text x="0" y="%d" dominant-baseline="middle" fill="%s">%s</text>`,
+			heatmapTop+int(wd)*cellStep+heatmapCellSize/2, textColor, wd.String()[:3])
+	}
+
+	if len(h.Days) > 0 {
+		offset := int(h.Days[0].Date.Weekday())
+		for i, d := range h.Days {
+			week := (offset + i) / 7
+			x := heatmapLeft + week*cellStep
+			y := heatmapTop + int(d.Date.Weekday())*cellStep
+
+			// Label the week that contains the first day of each month.
+			if d.Date.Day() == 1 || (i == 0 && d.Date.Day() < 8) {
+				fmt.Fprintf(sb, `<text x="%d" y="%d" fill="%s">%s</text>`, x, fontSize, textColor, d.Date.Format("Jan"))
+			}
+
+			fill := fmt.Sprintf(`fill="%s"`, gridColor)
+			if d.Value > 0 {
+				level := int(math.Ceil(d.Value/max*float64(len(heatmapOpacities)))) - 1
+				fill = fmt.Sprintf(`fill="%s" fill-opacity="%.2f"`, dataColor, heatmapOpacities[level])
+			}
+
+			fmt.Fprintf(sb, `<rect x="%d" y="%d" width="%d" height="%d" %s><title>%s</title></rect>`,
+				x, y, heatmapCellSize, heatmapCellSize, fill,
+				html.EscapeString(d.Date.Format("January 2, 2006")+": "+formatValue(d.Value)))
+		}
+	}
+
+	sb.WriteString(`</svg>`)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,89 @@
+package chart_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/chart"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBarChartWriteSVG(t *testing.T) {
+	t.Parallel()
+
+	c := &chart.BarChart{
+		Title:  "Books per year",
+		Points: []chart.Point{{"2018", 12}, {"2019", 7}, {"<script>", 1}},
+	}
+
+	sb := &strings.Builder{}
+	err := c.WriteSVG(sb)
+	require.NoError(t, err)
+
+	svg := sb.String()
+	require.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="480" height="200"`))
+	require.True(t, strings.HasSuffix(svg, `</svg>`))
+	require.Equal(t, 3, strings.Count(svg, "<rect "))
+	require.Contains(t, svg, "<title>Books per year</title>")
+	require.Contains(t, svg, "<title>2018: 12</title>")
+	require.Contains(t, svg, "&lt;script&gt;")
+	require.NotContains(t, svg, "<script>")
+
+	// The axis is rounded up from 12 to 20.
+	require.Contains(t, svg, ">20</text>")
+}
+
+func TestLineChartWriteSVG(t *testing.T) {
+	t.Parallel()
+
+	c := &chart.LineChart{
+		Width:  200,
+		Height: 100,
+		Points: []chart.Point{{"Jan", 1}, {"Feb", 0}, {"Mar", 3}},
+	}
+
+	sb := &strings.Builder{}
+	err := c.WriteSVG(sb)
+	require.NoError(t, err)
+
+	svg := sb.String()
+	require.Contains(t, svg, `viewBox="0 0 200 100"`)
+	require.Equal(t, 1, strings.Count(svg, "<polyline "))
+	require.Equal(t, 3, strings.Count(svg, "<circle "))
+}
+
+func TestChartWithoutPoints(t *testing.T) {
+	t.Parallel()
+
+	sb := &strings.Builder{}
+	err := (&chart.LineChart{}).WriteSVG(sb)
+	require.NoError(t, err)
+	require.NotContains(t, sb.String(), "<polyline ")
+	require.NotContains(t, sb.String(), "NaN")
+}
+
+func TestHeatmapWriteSVG(t *testing.T) {
+	t.Parallel()
+
+	// March 1, 2019 is a Friday so the 10 days span 3 weeks.
+	start := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	h := &chart.Heatmap{Title: "Books finished per day"}
+	for i := 0; i < 10; i++ {
+		h.Days = append(h.Days, chart.Day{Date: start.AddDate(0, 0, i)})
+	}
+	h.Days[2].Value = 1
+	h.Days[5].Value = 4
+
+	sb := &strings.Builder{}
+	err := h.WriteSVG(sb)
+	require.NoError(t, err)
+
+	svg := sb.String()
+	require.Contains(t, svg, `width="60"`)
+	require.Equal(t, 10, strings.Count(svg, "<rect "))
+	require.Contains(t, svg, "<title>March 3, 2019: 1</title>")
+	require.Equal(t, 1, strings.Count(svg, `fill-opacity="1.00"`))
+	require.Equal(t, 1, strings.Count(svg, `fill-opacity="0.30"`))
+	require.Contains(t, svg, ">Mar</text>")
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
 )
 
 type BooksPerTimeItem struct {
@@ -26,8 +27,9 @@ func scanRowsIntoBooksPerTimeItem(rows pgx.Rows) ([]BooksPerTimeItem, error) {
 	return booksPerTime, nil
 }
 
+// BooksPerYear returns the number of finished readings per year. A reread counts again in the year it was finished.
 func BooksPerYear(ctx context.Context, db dbconn, userID int64) ([]BooksPerTimeItem, error) {
-	rows, err := db.Query(ctx, "select date_trunc('year', finish_date), count(*) from books where user_id=$1 group by 1 order by 1 desc", userID)
+	rows, err := db.Query(ctx, "select date_trunc('year', finish_date), count(*) from books where user_id=$1 and status='finished' group by 1 order by 1 desc", userID)
 	if err != nil {
 		return nil, err
 	}
@@ -35,15 +37,354 @@ func BooksPerYear(ctx context.Context, db dbconn, userID int64) ([]BooksPerTimeI
 	return scanRowsIntoBooksPerTimeItem(rows)
 }
 
-func BooksPerMonthForLastYear(ctx context.Context, db dbconn, userID int64) ([]BooksPerTimeItem, error) {
-	rows, err := db.Query(ctx, `select months, count(books.id)
-from generate_series(date_trunc('month', now() - '1 year'::interval), date_trunc('month', now()), '1 month') as months
-	left join books on date_trunc('month', finish_date) = months and user_id=$1
+type ReadingsPerTimeItem struct {
+	Time     time.Time
+	Readings int32
+	Rereads  int32 // readings of a work that had already been finished
+}
+
+// ReadingsPerYear returns the number of finished readings per year and how many of them were rereads.
+func ReadingsPerYear(ctx context.Context, db dbconn, userID int64) ([]ReadingsPerTimeItem, error) {
+	rows, err := db.Query(ctx, `select date_trunc('year', finish_date), count(*), count(*) filter (where exists(
+	select 1
+	from books earlier
+	where earlier.work_id=books.work_id
+		and earlier.status='finished'
+		and (earlier.finish_date, earlier.id) < (books.finish_date, books.id)
+))
+from books
+where user_id=$1 and status='finished'
 group by 1
 order by 1 desc`, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	var readingsPerTime []ReadingsPerTimeItem
+	for rows.Next() {
+		var item ReadingsPerTimeItem
+		rows.Scan(&item.Time, &item.Readings, &item.Rereads)
+		readingsPerTime = append(readingsPerTime, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return readingsPerTime, nil
+}
+
+type PagesPerTimeItem struct {
+	Time  time.Time
+	Pages int64
+}
+
+// PagesPerYear returns the total pages of the books finished each year. Books without a page count are not included.
+func PagesPerYear(ctx context.Context, db dbconn, userID int64) ([]PagesPerTimeItem, error) {
+	rows, err := db.Query(ctx, "select date_trunc('year', finish_date), sum(page_count) from books where user_id=$1 and status='finished' and page_count is not null group by 1 order by 1 desc", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pagesPerTime []PagesPerTimeItem
+	for rows.Next() {
+		var item PagesPerTimeItem
+		rows.Scan(&item.Time, &item.Pages)
+		pagesPerTime = append(pagesPerTime, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return pagesPerTime, nil
+}
+
+type HoursPerTimeItem struct {
+	Time  time.Time
+	Hours float64
+}
+
+// HoursListenedPerYear returns the total hours of the audio books finished each year. Audio books without a length are
+// not included.
+func HoursListenedPerYear(ctx context.Context, db dbconn, userID int64) ([]HoursPerTimeItem, error) {
+	rows, err := db.Query(ctx, "select date_trunc('year', finish_date), (sum(audio_minutes) / 60.0)::float8 from books where user_id=$1 and status='finished' and format='audio' and audio_minutes is not null group by 1 order by 1 desc", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var hoursPerTime []HoursPerTimeItem
+	for rows.Next() {
+		var item HoursPerTimeItem
+		rows.Scan(&item.Time, &item.Hours)
+		hoursPerTime = append(hoursPerTime, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return hoursPerTime, nil
+}
+
+// BooksPerDay returns the number of books finished on each day from from through to. Every day is included even if no
+// books were finished.
+func BooksPerDay(ctx context.Context, db dbconn, userID int64, from, to time.Time) ([]BooksPerTimeItem, error) {
+	rows, err := db.Query(ctx, `select days.day, count(books.id)
+from (select generate_series($2::date, $3::date, '1 day')::date as day) as days
+	left join books on books.finish_date = days.day and books.user_id=$1 and books.status='finished'
+group by 1
+order by 1`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowsIntoBooksPerTimeItem(rows)
+}
+
+// AverageDaysPerBook returns the average number of days it took to read a book finished from from through to. Only
+// books with a known start date are included. It returns 0 if there are no such books.
+func AverageDaysPerBook(ctx context.Context, db dbconn, userID int64, from, to time.Time) (float64, error) {
+	var avgDays float64
+	err := db.QueryRow(ctx, "select coalesce(avg(finish_date - start_date + 1), 0)::float8 from books where user_id=$1 and status='finished' and start_date is not null and finish_date between $2::date and $3::date", userID, from, to).Scan(&avgDays)
+	if err != nil {
+		return 0, err
+	}
+
+	return avgDays, nil
+}
+
+// BooksPerMonth returns the number of books finished each month of the months that include from through to. Every
+// month is included even if no books were finished. Months are ordered newest first.
+func BooksPerMonth(ctx context.Context, db dbconn, userID int64, from, to time.Time) ([]BooksPerTimeItem, error) {
+	rows, err := db.Query(ctx, `select months, count(books.id)
+from generate_series(date_trunc('month', $2::date), date_trunc('month', $3::date), '1 month') as months
+	left join books on date_trunc('month', finish_date) = months and user_id=$1 and status='finished'
+group by 1
+order by 1 desc`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
 	return scanRowsIntoBooksPerTimeItem(rows)
 }
+
+// ReadingGoalProgress compares the books finished in a year to the reading goal for that year.
+type ReadingGoalProgress struct {
+	Year          int
+	Target        int32
+	FinishedCount int32
+	ExpectedCount float64 // books that would be finished by now at a steady pace to reach the target
+}
+
+// Percent returns the percentage of the target that has been finished.
+func (p *ReadingGoalProgress) Percent() float64 {
+	return float64(p.FinishedCount) / float64(p.Target) * 100
+}
+
+// AheadOfPace returns how many books ahead of a steady pace to reach the target the user is. It is negative when
+// behind pace.
+func (p *ReadingGoalProgress) AheadOfPace() float64 {
+	return float64(p.FinishedCount) - p.ExpectedCount
+}
+
+// NewReadingGoalProgress computes the progress toward goal as of now given the number of books finished in goal.Year.
+func NewReadingGoalProgress(goal *ReadingGoal, finishedCount int32, now time.Time) *ReadingGoalProgress {
+	return &ReadingGoalProgress{
+		Year:          goal.Year,
+		Target:        goal.Target,
+		FinishedCount: finishedCount,
+		ExpectedCount: float64(goal.Target) * yearElapsedFraction(goal.Year, now),
+	}
+}
+
+// yearElapsedFraction returns the fraction of year that has elapsed as of the end of the day of now. It is 0 for
+// future years and 1 for past years.
+func yearElapsedFraction(year int, now time.Time) float64 {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch {
+	case today.Before(start):
+		return 0
+	case !today.Before(end):
+		return 1
+	default:
+		return (today.Sub(start).Hours()/24 + 1) / (end.Sub(start).Hours() / 24)
+	}
+}
+
+// GetReadingGoalProgress returns the progress toward the reading goal of userID for year as of now. It returns nil if
+// there is no goal for year.
+func GetReadingGoalProgress(ctx context.Context, db dbconn, userID int64, year int, now time.Time) (*ReadingGoalProgress, error) {
+	goal, err := GetReadingGoal(ctx, db, userID, year)
+	if err != nil {
+		var nfErr *NotFoundError
+		if errors.As(err, &nfErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var finishedCount int32
+	err = db.QueryRow(ctx, "select count(*) from books where user_id=$1 and status='finished' and date_part('year', finish_date)=$2", userID, year).Scan(&finishedCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReadingGoalProgress(goal, finishedCount, now), nil
+}
+
+type AuthorCountItem struct {
+	AuthorID int64 // 0 only for books without linked authors counted by ComputeYearReview
+	Author   string
+	Count    int32
+}
+
+// TopAuthors returns the limit authors with the most finished books. A book with multiple authors counts for each of
+// them. Ties are ordered by name.
+func TopAuthors(ctx context.Context, db dbconn, userID int64, limit int) ([]AuthorCountItem, error) {
+	rows, err := db.Query(ctx, `select authors.id, authors.name, count(*)
+from books
+	join book_authors on book_authors.book_id=books.id
+	join authors on book_authors.author_id=authors.id
+where books.user_id=$1 and books.status='finished'
+group by authors.id
+order by 3 desc, 2
+limit $2`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var authors []AuthorCountItem
+	for rows.Next() {
+		var item AuthorCountItem
+		rows.Scan(&item.AuthorID, &item.Author, &item.Count)
+		authors = append(authors, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return authors, nil
+}
+
+type FormatsPerTimeItem struct {
+	Time  time.Time
+	Text  int32
+	Audio int32
+	Video int32
+}
+
+// FormatsPerYear returns the number of finished books of each format per year.
+func FormatsPerYear(ctx context.Context, db dbconn, userID int64) ([]FormatsPerTimeItem, error) {
+	rows, err := db.Query(ctx, `select date_trunc('year', finish_date),
+	count(*) filter (where format='text'),
+	count(*) filter (where format='audio'),
+	count(*) filter (where format='video')
+from books
+where user_id=$1 and status='finished'
+group by 1
+order by 1 desc`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var formatsPerTime []FormatsPerTimeItem
+	for rows.Next() {
+		var item FormatsPerTimeItem
+		rows.Scan(&item.Time, &item.Text, &item.Audio, &item.Video)
+		formatsPerTime = append(formatsPerTime, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return formatsPerTime, nil
+}
+
+type FormatCountItem struct {
+	Format string
+	Count  int32
+}
+
+// BooksPerFormat returns the number of books of each format finished from from through to. Formats without books are
+// not included.
+func BooksPerFormat(ctx context.Context, db dbconn, userID int64, from, to time.Time) ([]FormatCountItem, error) {
+	rows, err := db.Query(ctx, "select format, count(*) from books where user_id=$1 and status='finished' and finish_date between $2::date and $3::date group by 1 order by 2 desc, 1", userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var formats []FormatCountItem
+	for rows.Next() {
+		var item FormatCountItem
+		rows.Scan(&item.Format, &item.Count)
+		formats = append(formats, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return formats, nil
+}
+
+type LocationCountItem struct {
+	Location string
+	Count    int32
+}
+
+// LocationFrequency returns the number of finished books read at each location from most to least frequent. Books
+// without a location are not included.
+func LocationFrequency(ctx context.Context, db dbconn, userID int64) ([]LocationCountItem, error) {
+	rows, err := db.Query(ctx, `select location, count(*)
+from books
+where user_id=$1 and status='finished' and location is not null
+group by 1
+order by 2 desc, 1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []LocationCountItem
+	for rows.Next() {
+		var item LocationCountItem
+		rows.Scan(&item.Location, &item.Count)
+		locations = append(locations, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return locations, nil
+}
+
+type MonthCountItem struct {
+	Month time.Month
+	Count int32
+}
+
+// BooksPerMonthOfYear returns the number of finished books for each month of the year across all years. All 12 months
+// are returned ordered from most to fewest books.
+func BooksPerMonthOfYear(ctx context.Context, db dbconn, userID int64) ([]MonthCountItem, error) {
+	rows, err := db.Query(ctx, `select month, count(books.id)
+from generate_series(1, 12) as month
+	left join books on date_part('month', finish_date) = month and user_id=$1 and status='finished'
+group by 1
+order by 2 desc, 1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var months []MonthCountItem
+	for rows.Next() {
+		var month int32
+		var item MonthCountItem
+		rows.Scan(&month, &item.Count)
+		item.Month = time.Month(month)
+		months = append(months, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return months, nil
+}
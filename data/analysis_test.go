@@ -0,0 +1,28 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReadingGoalProgress(t *testing.T) {
+	t.Parallel()
+
+	goal := &data.ReadingGoal{Year: 2019, Target: 24}
+
+	// July 2 is the 183rd day of 2019 so half the target should be finished.
+	progress := data.NewReadingGoalProgress(goal, 15, time.Date(2019, 7, 2, 18, 0, 0, 0, time.UTC))
+	require.InDelta(t, 12.033, progress.ExpectedCount, 0.001)
+	require.InDelta(t, 2.967, progress.AheadOfPace(), 0.001)
+	require.InDelta(t, 62.5, progress.Percent(), 0.001)
+
+	progress = data.NewReadingGoalProgress(goal, 20, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.EqualValues(t, 24, progress.ExpectedCount)
+	require.EqualValues(t, -4, progress.AheadOfPace())
+
+	progress = data.NewReadingGoalProgress(goal, 0, time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.EqualValues(t, 0, progress.ExpectedCount)
+}
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// APIToken is a credential that authenticates API requests as its user. Only a digest of the token is stored so the
+// token itself is only available when it is created.
+type APIToken struct {
+	ID           int64
+	UserID       int64
+	Name         string
+	LastUsedTime time.Time // zero if never used
+	InsertTime   time.Time
+}
+
+// generateToken returns a new random token that is safe to use in a URL.
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// digestToken returns the digest of token that is stored instead of the token.
+func digestToken(token string) []byte {
+	digest := sha256.Sum256([]byte(token))
+	return digest[:]
+}
+
+// CreateAPIToken creates an API token for the user. It returns the token which must be shown to the user
+// immediately as it cannot be retrieved later.
+func CreateAPIToken(ctx context.Context, db dbconn, userID int64, name string) (string, *APIToken, error) {
+	name = strings.TrimSpace(name)
+
+	v := validate.New()
+	v.Presence("name", name)
+	if v.Err() != nil {
+		return "", nil, v.Err()
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	apiToken := &APIToken{UserID: userID, Name: name}
+	err = db.QueryRow(ctx, "insert into api_tokens(user_id, name, token_digest) values($1, $2, $3) returning id, insert_time",
+		userID, name, digestToken(token),
+	).Scan(&apiToken.ID, &apiToken.InsertTime)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, apiToken, nil
+}
+
+// GetAPITokens returns the API tokens of the user with the most recently created first.
+func GetAPITokens(ctx context.Context, db dbconn, userID int64) ([]*APIToken, error) {
+	rows, err := db.Query(ctx, "select id, user_id, name, last_used_time, insert_time from api_tokens where user_id=$1 order by insert_time desc", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiTokens []*APIToken
+	for rows.Next() {
+		var apiToken APIToken
+		var lastUsedTime *time.Time
+		rows.Scan(&apiToken.ID, &apiToken.UserID, &apiToken.Name, &lastUsedTime, &apiToken.InsertTime)
+		if lastUsedTime != nil {
+			apiToken.LastUsedTime = *lastUsedTime
+		}
+		apiTokens = append(apiTokens, &apiToken)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return apiTokens, nil
+}
+
+// DeleteAPIToken deletes the API token specified by apiTokenID belonging to the user. It returns a NotFoundError if
+// the token cannot be found.
+func DeleteAPIToken(ctx context.Context, db dbconn, userID int64, apiTokenID int64) error {
+	commandTag, err := db.Exec(ctx, "delete from api_tokens where id=$1 and user_id=$2", apiTokenID, userID)
+	if err != nil {
+		return err
+	}
+	if string(commandTag) != "DELETE 1" {
+		return &NotFoundError{target: fmt.Sprintf("api token id=%d", apiTokenID)}
+	}
+
+	return nil
+}
+
+// GetUserMinByAPIToken returns the user that token authenticates and records that the token was used. It returns a
+// NotFoundError if the token is not valid.
+func GetUserMinByAPIToken(ctx context.Context, db dbconn, token string) (*UserMin, error) {
+	var user UserMin
+	err := db.QueryRow(ctx, `update api_tokens
+set last_used_time=now()
+from users
+where api_tokens.user_id=users.id and api_tokens.token_digest=$1
+returning users.id, users.username`,
+		digestToken(token),
+	).Scan(&user.ID, &user.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &NotFoundError{target: "api token"}
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
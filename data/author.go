@@ -0,0 +1,316 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// authorSeparatorRegexp matches the separators between the names of multiple authors of a book. It must match the
+// split in migration 020_create_authors.
+var authorSeparatorRegexp = regexp.MustCompile(`\s+(?:and|&)\s+|\s*;\s*`)
+
+// Author is a person who wrote books. Books are linked to an author when a name in their author matches the name or an
+// alias of the author ignoring case and whitespace.
+type Author struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Aliases    []string
+	InsertTime time.Time
+	UpdateTime time.Time
+}
+
+// AuthorMin is the ID and name of an author.
+type AuthorMin struct {
+	ID   int64
+	Name string
+}
+
+// AuthorListItem is an author and the number of books linked to the author.
+type AuthorListItem struct {
+	ID        int64
+	Name      string
+	BookCount int32
+}
+
+// normalizeAuthorName lowercases name and collapses whitespace like the normalize_book_text SQL function.
+func normalizeAuthorName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// SplitAuthorNames splits the author of a book into the names of its authors. Names are separated by "and", "&", or
+// ";". Blank and duplicate names are removed.
+func SplitAuthorNames(author string) []string {
+	seen := make(map[string]struct{})
+	var names []string
+	for _, name := range authorSeparatorRegexp.Split(author, -1) {
+		name = strings.TrimSpace(name)
+		key := normalizeAuthorName(name)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (author *Author) Normalize() {
+	author.Name = strings.TrimSpace(author.Name)
+
+	seen := map[string]struct{}{normalizeAuthorName(author.Name): struct{}{}}
+	aliases := make([]string, 0, len(author.Aliases))
+	for _, alias := range author.Aliases {
+		alias = strings.TrimSpace(alias)
+		key := normalizeAuthorName(alias)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		aliases = append(aliases, alias)
+	}
+	author.Aliases = aliases
+}
+
+func (author *Author) Validate() validate.Errors {
+	v := validate.New()
+	v.Presence("name", author.Name)
+
+	if len(SplitAuthorNames(author.Name)) > 1 {
+		v.Add("name", errors.New(`cannot contain "and", "&", or ";"`))
+	}
+
+	for _, alias := range author.Aliases {
+		if len(SplitAuthorNames(alias)) > 1 {
+			v.Add("aliases", errors.New(`cannot contain "and", "&", or ";"`))
+			break
+		}
+	}
+
+	if v.Err() != nil {
+		return v.Err().(validate.Errors)
+	}
+
+	return nil
+}
+
+// findOrCreateAuthor returns the ID of the author of userID whose name or alias matches name. The author is created if
+// it does not exist.
+func findOrCreateAuthor(ctx context.Context, db dbconn, userID int64, name string) (int64, error) {
+	var authorID int64
+	err := db.QueryRow(ctx, `select id from authors where user_id=$1 and normalize_book_text(name)=normalize_book_text($2)
+union all
+select author_id from author_aliases where user_id=$1 and normalize_book_text(name)=normalize_book_text($2)
+limit 1`, userID, name).Scan(&authorID)
+	if err == nil {
+		return authorID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+
+	err = db.QueryRow(ctx, `insert into authors(user_id, name) values($1, $2)
+on conflict (user_id, normalize_book_text(name)) do update set name=authors.name
+returning id`, userID, name).Scan(&authorID)
+	return authorID, err
+}
+
+// setBookAuthors replaces the authors of the book specified by bookID with the authors named in author. Authors that
+// do not yet exist for the user are created.
+func setBookAuthors(ctx context.Context, db dbconn, userID int64, bookID int64, author string) error {
+	_, err := db.Exec(ctx, "delete from book_authors where book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	for i, name := range SplitAuthorNames(author) {
+		authorID, err := findOrCreateAuthor(ctx, db, userID, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(ctx, "insert into book_authors(book_id, author_id, position) values($1, $2, $3) on conflict do nothing", bookID, authorID, i+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GetAuthor(ctx context.Context, db dbconn, authorID int64) (*Author, error) {
+	var author Author
+	err := db.QueryRow(ctx, `select id, user_id, name,
+	array(select name from author_aliases where author_id=authors.id order by name),
+	insert_time, update_time
+from authors
+where id=$1`, authorID).Scan(&author.ID, &author.UserID, &author.Name, &author.Aliases, &author.InsertTime, &author.UpdateTime)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &NotFoundError{target: fmt.Sprintf("author id=%d", authorID)}
+		}
+		return nil, err
+	}
+
+	return &author, nil
+}
+
+// GetAuthors returns the authors of userID that have at least one book ordered by name.
+func GetAuthors(ctx context.Context, db dbconn, userID int64) ([]AuthorListItem, error) {
+	rows, err := db.Query(ctx, `select authors.id, authors.name, count(*)
+from authors
+	join book_authors on book_authors.author_id=authors.id
+where authors.user_id=$1
+group by authors.id
+order by lower(authors.name), authors.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var authors []AuthorListItem
+	for rows.Next() {
+		var item AuthorListItem
+		rows.Scan(&item.ID, &item.Name, &item.BookCount)
+		authors = append(authors, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return authors, nil
+}
+
+// UpdateAuthor updates the Name and Aliases of author in the database. It uses author.ID as the row ID to update. A
+// previous name that differs from the new name becomes an alias so books that still use it stay linked to author.
+// Another author of the same user whose name or alias matches the new name or an alias is merged into author: its
+// books are linked to author and its name and aliases become aliases of author.
+func UpdateAuthor(ctx context.Context, db dbconn, author Author) error {
+	author.Normalize()
+	if verrs := author.Validate(); verrs != nil {
+		return verrs
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var previousName string
+	err = tx.QueryRow(ctx, "select user_id, name from authors where id=$1 for update", author.ID).Scan(&author.UserID, &previousName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &NotFoundError{target: fmt.Sprintf("author id=%d", author.ID)}
+		}
+		return err
+	}
+
+	author.Aliases = append(author.Aliases, previousName)
+	author.Normalize()
+
+	names := append([]string{author.Name}, author.Aliases...)
+	rows, err := tx.Query(ctx, `select distinct id from (
+	select id from authors where user_id=$1 and normalize_book_text(name)=any(select normalize_book_text(unnest($2::text[])))
+	union all
+	select author_id from author_aliases where user_id=$1 and normalize_book_text(name)=any(select normalize_book_text(unnest($2::text[])))
+) t
+where id<>$3`, author.UserID, names, author.ID)
+	if err != nil {
+		return err
+	}
+	var mergeIDs []int64
+	for rows.Next() {
+		var id int64
+		rows.Scan(&id)
+		mergeIDs = append(mergeIDs, id)
+	}
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	if len(mergeIDs) > 0 {
+		rows, err := tx.Query(ctx, `select name from authors where id=any($1)
+union all
+select name from author_aliases where author_id=any($1)`, mergeIDs)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var name string
+			rows.Scan(&name)
+			author.Aliases = append(author.Aliases, name)
+		}
+		if rows.Err() != nil {
+			return rows.Err()
+		}
+		author.Normalize()
+
+		for _, id := range mergeIDs {
+			err = mergeAuthor(ctx, tx, author.ID, id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.Exec(ctx, "delete from author_aliases where author_id=$1", author.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "update authors set name=$1 where id=$2", author.Name, author.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(author.Aliases) > 0 {
+		_, err = tx.Exec(ctx, "insert into author_aliases(author_id, user_id, name) select $1, $2, unnest($3::text[])", author.ID, author.UserID, author.Aliases)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// mergeAuthor links the books of the author specified by fromID to the author specified by intoID and deletes the
+// from author.
+func mergeAuthor(ctx context.Context, db dbconn, intoID int64, fromID int64) error {
+	_, err := db.Exec(ctx, `insert into book_authors(book_id, author_id, position)
+select book_id, $1, position from book_authors where author_id=$2
+on conflict do nothing`, intoID, fromID)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, "delete from authors where id=$1", fromID)
+	return err
+}
+
+// GetBooksByAuthor returns all books linked to the author specified by authorID regardless of status.
+func GetBooksByAuthor(ctx context.Context, db dbconn, authorID int64) ([]*Book, error) {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books
+where exists(select 1 from book_authors where book_authors.book_id=books.id and book_authors.author_id=$1)
+order by finish_date desc nulls first, insert_time desc`,
+		authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRowsIntoBooks(rows)
+}
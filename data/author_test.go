@@ -0,0 +1,103 @@
+package data_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAuthorNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		author string
+		names  []string
+	}{
+		{"J.R.R. Tolkien", []string{"J.R.R. Tolkien"}},
+		{"Neil Gaiman and Terry Pratchett", []string{"Neil Gaiman", "Terry Pratchett"}},
+		{"Penn & Teller; Someone Else", []string{"Penn", "Teller", "Someone Else"}},
+		{"Alexander Anderson", []string{"Alexander Anderson"}},
+		{"John Milton and john  milton", []string{"John Milton"}},
+		{" ; ", nil},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.names, data.SplitAuthorNames(tt.author), tt.author)
+	}
+}
+
+func TestAuthorNormalize(t *testing.T) {
+	t.Parallel()
+
+	author := data.Author{Name: " J.R.R. Tolkien ", Aliases: []string{"JRR Tolkien", " ", "j.r.r.  tolkien", "jrr tolkien", "Tolkien"}}
+	author.Normalize()
+	require.Equal(t, "J.R.R. Tolkien", author.Name)
+	require.Equal(t, []string{"JRR Tolkien", "Tolkien"}, author.Aliases)
+}
+
+func TestAuthorValidate(t *testing.T) {
+	t.Parallel()
+
+	author := data.Author{Name: "J.R.R. Tolkien", Aliases: []string{"JRR Tolkien"}}
+	require.Nil(t, author.Validate())
+
+	author = data.Author{Name: "", Aliases: []string{"Penn and Teller"}}
+	verr := author.Validate()
+	require.Contains(t, verr, "name")
+	require.Contains(t, verr, "aliases")
+}
+
+func TestUpdateAuthorRenameKeepsBooksLinked(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, os.Getenv("BOOKLOG_TEST_DB_CONN_STRING"))
+	require.NoError(t, err)
+	defer closeConn(t, conn)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, "insert into users(username, password_digest) values('test', 'x') returning id").Scan(&userID)
+	require.NoError(t, err)
+
+	book, err := data.CreateBook(ctx, tx, data.Book{
+		UserID:     userID,
+		Title:      "Paradise Lost",
+		Author:     "Jon Milton",
+		Status:     data.BookStatusFinished,
+		FinishDate: time.Date(2019, 6, 17, 0, 0, 0, 0, time.UTC),
+		Format:     "text",
+	})
+	require.NoError(t, err)
+
+	book, err = data.GetBook(ctx, tx, book.ID)
+	require.NoError(t, err)
+	require.Len(t, book.Authors, 1)
+	authorID := book.Authors[0].ID
+
+	err = data.UpdateAuthor(ctx, tx, data.Author{ID: authorID, Name: "John Milton"})
+	require.NoError(t, err)
+
+	author, err := data.GetAuthor(ctx, tx, authorID)
+	require.NoError(t, err)
+	require.Equal(t, "John Milton", author.Name)
+	require.Equal(t, []string{"Jon Milton"}, author.Aliases)
+
+	book.Location = "Library"
+	err = data.UpdateBook(ctx, tx, *book)
+	require.NoError(t, err)
+
+	book, err = data.GetBook(ctx, tx, book.ID)
+	require.NoError(t, err)
+	require.Equal(t, []data.AuthorMin{{ID: authorID, Name: "John Milton"}}, book.Authors)
+}
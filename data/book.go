@@ -3,6 +3,8 @@ package data
 import (
 	"context"
 	"fmt"
+	"math"
+	"regexp"
 	"strings"
 	"time"
 
@@ -11,16 +13,42 @@ import (
 	errors "golang.org/x/xerrors"
 )
 
+// Book statuses. Each status is a shelf a book can be on.
+const (
+	BookStatusWantToRead = "want_to_read"
+	BookStatusReading    = "reading"
+	BookStatusFinished   = "finished"
+	BookStatusAbandoned  = "abandoned"
+)
+
+// BookStatuses lists every book status in shelf order.
+var BookStatuses = []string{BookStatusReading, BookStatusWantToRead, BookStatusFinished, BookStatusAbandoned}
+
+var isbnRegexp = regexp.MustCompile(`^(\d{9}[\dX]|\d{13})$`)
+
+// Book is one reading of a work. Rereading a book is another Book with the same WorkID.
 type Book struct {
-	ID         int64
-	UserID     int64
-	Title      string
-	Author     string
-	FinishDate time.Time
-	Format     string
-	Location   string
-	InsertTime time.Time
-	UpdateTime time.Time
+	ID             int64
+	UserID         int64
+	WorkID         int64 // set from Title and Author; read only
+	Title          string
+	Author         string
+	Status         string
+	StartDate      time.Time // zero means unknown
+	FinishDate     time.Time // zero unless finished or abandoned
+	Format         string
+	Location       string
+	Rating         float64 // 0 means unrated
+	Review         string
+	ISBN           string
+	PageCount      int32 // 0 means unknown
+	AudioMinutes   int32 // length of an audio book; 0 means unknown
+	Series         string
+	SeriesPosition float64 // 0 means unnumbered
+	Tags           []string
+	Authors        []AuthorMin // authors named in Author; read only
+	InsertTime     time.Time
+	UpdateTime     time.Time
 }
 
 func (book *Book) Normalize() {
@@ -28,6 +56,10 @@ func (book *Book) Normalize() {
 	book.Author = strings.TrimSpace(book.Author)
 	book.Format = strings.TrimSpace(book.Format)
 	book.Location = strings.TrimSpace(book.Location)
+	book.Series = strings.TrimSpace(book.Series)
+	book.Review = strings.TrimSpace(book.Review)
+	book.ISBN = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(book.ISBN))
+	book.Tags = NormalizeTags(book.Tags)
 }
 
 func (book *Book) Validate() validate.Errors {
@@ -35,6 +67,13 @@ func (book *Book) Validate() validate.Errors {
 	v.Presence("title", book.Title)
 	v.Presence("author", book.Author)
 
+	allowedStatuses := map[string]struct{}{BookStatusWantToRead: struct{}{}, BookStatusReading: struct{}{}, BookStatusFinished: struct{}{}, BookStatusAbandoned: struct{}{}}
+
+	v.Presence("status", book.Status)
+	if _, ok := allowedStatuses[book.Status]; !ok {
+		v.Add("status", errors.New(`must be "want_to_read", "reading", "finished", or "abandoned"`))
+	}
+
 	allowedFormats := map[string]struct{}{"text": struct{}{}, "audio": struct{}{}, "video": struct{}{}}
 
 	v.Presence("format", book.Format)
@@ -42,10 +81,49 @@ func (book *Book) Validate() validate.Errors {
 		v.Add("finishDate", errors.New(`must be "text", "audio", or "video"`))
 	}
 
+	if book.Status == BookStatusFinished && book.FinishDate.IsZero() {
+		v.Add("finishDate", errors.New("cannot be blank when finished"))
+	}
+
 	if book.FinishDate.After(time.Now()) {
 		v.Add("finishDate", errors.New("cannot be in future"))
 	}
 
+	if !book.StartDate.IsZero() && !book.FinishDate.IsZero() && book.StartDate.After(book.FinishDate) {
+		v.Add("startDate", errors.New("cannot be after finish date"))
+	}
+
+	if book.Rating != 0 {
+		if book.Rating < 1 || book.Rating > 5 || book.Rating*2 != math.Trunc(book.Rating*2) {
+			v.Add("rating", errors.New("must be from 1 to 5 in half star steps"))
+		}
+	}
+
+	if book.ISBN != "" && !isbnRegexp.MatchString(book.ISBN) {
+		v.Add("isbn", errors.New("must be 10 or 13 digits"))
+	}
+
+	if book.PageCount < 0 {
+		v.Add("pageCount", errors.New("cannot be negative"))
+	}
+
+	if book.AudioMinutes < 0 {
+		v.Add("audioMinutes", errors.New("cannot be negative"))
+	}
+
+	if book.AudioMinutes != 0 && book.Format != "audio" {
+		v.Add("audioMinutes", errors.New("is only for audio books"))
+	}
+
+	if book.SeriesPosition != 0 {
+		if book.Series == "" {
+			v.Add("seriesPosition", errors.New("cannot be set without a series"))
+		}
+		if book.SeriesPosition < 0 || book.SeriesPosition >= 10000 || book.SeriesPosition*10 != math.Trunc(book.SeriesPosition*10) {
+			v.Add("seriesPosition", errors.New("must be a positive number with at most one decimal place"))
+		}
+	}
+
 	if v.Err() != nil {
 		return v.Err().(validate.Errors)
 	}
@@ -53,77 +131,179 @@ func (book *Book) Validate() validate.Errors {
 	return nil
 }
 
-// CreateBook inserts a book into the database. It ignores the ID, InsertTime, and UpdateTime fields.
+// CreateBook inserts a book into the database. It ignores the ID, WorkID, InsertTime, and UpdateTime fields. The book
+// is a reading of the existing work with the same title and author if there is one.
 func CreateBook(ctx context.Context, db dbconn, book Book) (*Book, error) {
 	book.Normalize()
 	if verrs := book.Validate(); verrs != nil {
 		return nil, verrs
 	}
 
-	var location *string
-	if len(book.Location) > 0 {
-		location = &book.Location
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	err := db.QueryRow(ctx, "insert into books(user_id, title, author, finish_date, format, location) values($1, $2, $3, $4, $5, $6) returning id, insert_time, update_time",
+	book.WorkID, err = findOrCreateWork(ctx, tx, book.UserID, book.Title, book.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, "insert into books(user_id, work_id, title, author, status, start_date, finish_date, format, location, rating, review, isbn, page_count, audio_minutes, series, series_position) values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) returning id, insert_time, update_time",
 		book.UserID,
+		book.WorkID,
 		book.Title,
 		book.Author,
-		book.FinishDate,
+		book.Status,
+		nullDate(book.StartDate),
+		nullDate(book.FinishDate),
 		book.Format,
-		location,
+		nullString(book.Location),
+		nullFloat64(book.Rating),
+		nullString(book.Review),
+		nullString(book.ISBN),
+		nullInt32(book.PageCount),
+		nullInt32(book.AudioMinutes),
+		nullString(book.Series),
+		nullFloat64(book.SeriesPosition),
 	).Scan(&book.ID, &book.InsertTime, &book.UpdateTime)
 	if err != nil {
 		return nil, err
 	}
 
+	err = setBookTags(ctx, tx, book.UserID, book.ID, book.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setBookAuthors(ctx, tx, book.UserID, book.ID, book.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &book, nil
 }
 
-// Update book updates the Title, Author, FinishDate, and Format fields of book in the database. It uses book.ID as the
-// row ID to update.
+// Update book updates the Title, Author, Status, StartDate, FinishDate, Format, Location, Rating, Review, ISBN,
+// PageCount, AudioMinutes, Series, SeriesPosition, and Tags fields of book in the database. It uses book.ID as the row
+// ID to update. Title, Author, ISBN, PageCount, Series, SeriesPosition, and Tags describe the work so they are updated
+// on every reading of the work. Changing the title or author renames the work, or merges it into the work that already
+// has the new title and author.
 func UpdateBook(ctx context.Context, db dbconn, book Book) error {
 	book.Normalize()
 	if verrs := book.Validate(); verrs != nil {
 		return verrs
 	}
 
-	var location *string
-	if len(book.Location) > 0 {
-		location = &book.Location
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	commandTag, err := db.Exec(ctx, "update books set title=$1, author=$2, finish_date=$3, format=$4, location=$5 where id=$6",
-		book.Title,
-		book.Author,
-		book.FinishDate,
+	var userID int64
+	err = tx.QueryRow(ctx, "select user_id, work_id from books where id=$1 for update", book.ID).Scan(&userID, &book.WorkID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &NotFoundError{target: fmt.Sprintf("book id=%d", book.ID)}
+		}
+		return err
+	}
+
+	book.WorkID, err = renameWork(ctx, tx, userID, book.WorkID, book.Title, book.Author)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "update books set status=$1, start_date=$2, finish_date=$3, format=$4, location=$5, rating=$6, review=$7, audio_minutes=$8 where id=$9",
+		book.Status,
+		nullDate(book.StartDate),
+		nullDate(book.FinishDate),
 		book.Format,
-		location,
-		book.ID)
+		nullString(book.Location),
+		nullFloat64(book.Rating),
+		nullString(book.Review),
+		nullInt32(book.AudioMinutes),
+		book.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = updateWorkReadings(ctx, tx, userID, book.WorkID, book)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReadingDays returns the number of days it took to read book counting both the start and finish days. It returns 0
+// if the start or finish date is unknown.
+func (book *Book) ReadingDays() int {
+	if book.StartDate.IsZero() || book.FinishDate.IsZero() {
+		return 0
+	}
+	return int(book.FinishDate.Sub(book.StartDate).Hours()/24) + 1
+}
+
+// FinishBook moves the book of userID specified by bookID to the finished shelf with a finish date of today. A book that
+// is already finished keeps its finish date. It returns a NotFoundError if the book cannot be found.
+func FinishBook(ctx context.Context, db dbconn, userID int64, bookID int64) error {
+	commandTag, err := db.Exec(ctx, "update books set status=$1, finish_date=current_date where id=$2 and user_id=$3 and status<>$1", BookStatusFinished, bookID, userID)
 	if err != nil {
 		return err
 	}
 	if string(commandTag) != "UPDATE 1" {
-		return &NotFoundError{target: fmt.Sprintf("book id=%d", book.ID)}
+		var exists bool
+		err := db.QueryRow(ctx, "select exists(select 1 from books where id=$1 and user_id=$2)", bookID, userID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return &NotFoundError{target: fmt.Sprintf("book id=%d", bookID)}
+		}
 	}
 
 	return nil
 }
 
-// DeleteBook deletes the book specified by bookID. It returns a NotFoundError if the book
-// cannot be found.
+// DeleteBook deletes the book specified by bookID. Its work is deleted too if it was the only reading. It returns a
+// NotFoundError if the book cannot be found.
 func DeleteBook(ctx context.Context, db dbconn, bookID int64) error {
-	commandTag, err := db.Exec(ctx, "delete from books where id=$1", bookID)
-	if string(commandTag) != "DELETE 1" {
-		return &NotFoundError{target: fmt.Sprintf("book id=%d", bookID)}
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
 	}
-	return err
+	defer tx.Rollback(ctx)
+
+	var workID int64
+	err = tx.QueryRow(ctx, "delete from books where id=$1 returning work_id", bookID).Scan(&workID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &NotFoundError{target: fmt.Sprintf("book id=%d", bookID)}
+		}
+		return err
+	}
+
+	err = deleteWorkIfUnread(ctx, tx, workID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func GetBook(ctx context.Context, db dbconn, bookID int64) (*Book, error) {
 	var book Book
 	err := ScanIntoBook(
-		db.QueryRow(ctx, "select id, user_id, title, author, finish_date, format, location, insert_time, update_time from books where id=$1", bookID),
+		db.QueryRow(ctx, "select "+bookColumns+" from books where id=$1", bookID),
 		&book,
 	)
 	if err != nil {
@@ -136,19 +316,91 @@ func GetBook(ctx context.Context, db dbconn, bookID int64) (*Book, error) {
 	return &book, nil
 }
 
+// bookColumns is the select list read by ScanIntoBook.
+const bookColumns = `id, user_id, work_id, title, author, status, start_date, finish_date, format, location, rating, review, isbn, page_count, audio_minutes,
+	series, series_position,
+	array(select tags.name from book_tags join tags on book_tags.tag_id=tags.id where book_tags.book_id=books.id order by tags.name),
+	array(select authors.id from book_authors join authors on book_authors.author_id=authors.id where book_authors.book_id=books.id order by book_authors.position),
+	array(select authors.name from book_authors join authors on book_authors.author_id=authors.id where book_authors.book_id=books.id order by book_authors.position),
+	insert_time, update_time`
+
 func ScanIntoBook(s scanner, book *Book) error {
-	var location *string
-	err := s.Scan(&book.ID, &book.UserID, &book.Title, &book.Author, &book.FinishDate, &book.Format, &location, &book.InsertTime, &book.UpdateTime)
+	var startDate, finishDate *time.Time
+	var location, review, isbn, series *string
+	var rating, seriesPosition *float64
+	var pageCount, audioMinutes *int32
+	var authorIDs []int64
+	var authorNames []string
+	err := s.Scan(&book.ID, &book.UserID, &book.WorkID, &book.Title, &book.Author, &book.Status, &startDate, &finishDate, &book.Format, &location, &rating, &review, &isbn, &pageCount, &audioMinutes, &series, &seriesPosition, &book.Tags, &authorIDs, &authorNames, &book.InsertTime, &book.UpdateTime)
 	if err != nil {
 		return err
 	}
 
+	book.Authors = make([]AuthorMin, len(authorIDs))
+	for i := range authorIDs {
+		book.Authors[i] = AuthorMin{ID: authorIDs[i], Name: authorNames[i]}
+	}
+
+	if startDate == nil {
+		book.StartDate = time.Time{}
+	} else {
+		book.StartDate = *startDate
+	}
+
+	if finishDate == nil {
+		book.FinishDate = time.Time{}
+	} else {
+		book.FinishDate = *finishDate
+	}
+
 	if location == nil {
 		book.Location = ""
 	} else {
 		book.Location = *location
 	}
 
+	if rating == nil {
+		book.Rating = 0
+	} else {
+		book.Rating = *rating
+	}
+
+	if review == nil {
+		book.Review = ""
+	} else {
+		book.Review = *review
+	}
+
+	if isbn == nil {
+		book.ISBN = ""
+	} else {
+		book.ISBN = *isbn
+	}
+
+	if pageCount == nil {
+		book.PageCount = 0
+	} else {
+		book.PageCount = *pageCount
+	}
+
+	if audioMinutes == nil {
+		book.AudioMinutes = 0
+	} else {
+		book.AudioMinutes = *audioMinutes
+	}
+
+	if series == nil {
+		book.Series = ""
+	} else {
+		book.Series = *series
+	}
+
+	if seriesPosition == nil {
+		book.SeriesPosition = 0
+	} else {
+		book.SeriesPosition = *seriesPosition
+	}
+
 	return nil
 }
 
@@ -167,10 +419,10 @@ func ScanRowsIntoBooks(rows pgx.Rows) ([]*Book, error) {
 }
 
 func GetAllBooks(ctx context.Context, db dbconn, userID int64) ([]*Book, error) {
-	rows, err := db.Query(ctx, `select id, user_id, title, author, finish_date, format, location, insert_time, update_time
+	rows, err := db.Query(ctx, `select `+bookColumns+`
 from books
 where user_id=$1
-order by finish_date desc`,
+order by finish_date desc nulls first, insert_time desc`,
 		userID)
 	if err != nil {
 		return nil, err
@@ -178,3 +430,74 @@ order by finish_date desc`,
 
 	return ScanRowsIntoBooks(rows)
 }
+
+// EachBook calls fn for each book of userID in the same order as GetAllBooks without loading all books into memory.
+// Iteration stops at the first error.
+func EachBook(ctx context.Context, db dbconn, userID int64, fn func(*Book) error) error {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books
+where user_id=$1
+order by finish_date desc nulls first, insert_time desc`,
+		userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book Book
+		err := ScanIntoBook(rows, &book)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&book)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetBooksByStatus returns the books on the status shelf. Books are ordered by most recently finished, then most
+// recently started, then most recently added.
+func GetBooksByStatus(ctx context.Context, db dbconn, userID int64, status string) ([]*Book, error) {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books
+where user_id=$1 and status=$2
+order by finish_date desc nulls last, start_date desc nulls last, insert_time desc`,
+		userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRowsIntoBooks(rows)
+}
+
+// FindDuplicateBook returns a book of book.UserID that is likely the same reading as book. Books are duplicates when
+// their title and author match ignoring case and whitespace and their finish dates are the same. It returns nil if
+// there is no duplicate.
+func FindDuplicateBook(ctx context.Context, db dbconn, book Book) (*Book, error) {
+	var duplicate Book
+	err := ScanIntoBook(
+		db.QueryRow(ctx, `select `+bookColumns+`
+from books
+where user_id=$1
+	and normalize_book_text(title)=normalize_book_text($2)
+	and normalize_book_text(author)=normalize_book_text($3)
+	and finish_date is not distinct from $4
+order by insert_time
+limit 1`,
+			book.UserID, book.Title, book.Author, nullDate(book.FinishDate)),
+		&duplicate,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &duplicate, nil
+}
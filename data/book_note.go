@@ -0,0 +1,196 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// Book note kinds. A note is written by the reader. A quote is copied from the book.
+const (
+	BookNoteKindNote  = "note"
+	BookNoteKindQuote = "quote"
+)
+
+// BookNote is a private note or quote taken while reading a book.
+type BookNote struct {
+	ID         int64
+	BookID     int64
+	Kind       string
+	Body       string
+	Page       int32 // 0 means no page
+	InsertTime time.Time
+	UpdateTime time.Time
+}
+
+func (note *BookNote) Normalize() {
+	note.Kind = strings.TrimSpace(note.Kind)
+	note.Body = strings.TrimSpace(note.Body)
+}
+
+func (note *BookNote) Validate() validate.Errors {
+	v := validate.New()
+	v.Presence("body", note.Body)
+
+	if note.Kind != BookNoteKindNote && note.Kind != BookNoteKindQuote {
+		v.Add("kind", errors.New(`must be "note" or "quote"`))
+	}
+
+	if note.Page < 0 {
+		v.Add("page", errors.New("cannot be negative"))
+	}
+
+	if v.Err() != nil {
+		return v.Err().(validate.Errors)
+	}
+
+	return nil
+}
+
+// CreateBookNote inserts a note into the database. It ignores the ID, InsertTime, and UpdateTime fields.
+func CreateBookNote(ctx context.Context, db dbconn, note BookNote) (*BookNote, error) {
+	note.Normalize()
+	if verrs := note.Validate(); verrs != nil {
+		return nil, verrs
+	}
+
+	err := db.QueryRow(ctx, "insert into book_notes(book_id, kind, body, page) values($1, $2, $3, $4) returning id, insert_time, update_time",
+		note.BookID,
+		note.Kind,
+		note.Body,
+		nullInt32(note.Page),
+	).Scan(&note.ID, &note.InsertTime, &note.UpdateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// UpdateBookNote updates the Kind, Body, and Page fields of note in the database. It uses note.ID as the row ID to
+// update.
+func UpdateBookNote(ctx context.Context, db dbconn, note BookNote) error {
+	note.Normalize()
+	if verrs := note.Validate(); verrs != nil {
+		return verrs
+	}
+
+	commandTag, err := db.Exec(ctx, "update book_notes set kind=$1, body=$2, page=$3 where id=$4",
+		note.Kind,
+		note.Body,
+		nullInt32(note.Page),
+		note.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if string(commandTag) != "UPDATE 1" {
+		return &NotFoundError{target: fmt.Sprintf("book note id=%d", note.ID)}
+	}
+
+	return nil
+}
+
+// DeleteBookNote deletes the note specified by noteID. It returns a NotFoundError if the note cannot be found.
+func DeleteBookNote(ctx context.Context, db dbconn, noteID int64) error {
+	commandTag, err := db.Exec(ctx, "delete from book_notes where id=$1", noteID)
+	if err != nil {
+		return err
+	}
+	if string(commandTag) != "DELETE 1" {
+		return &NotFoundError{target: fmt.Sprintf("book note id=%d", noteID)}
+	}
+
+	return nil
+}
+
+const bookNoteColumns = `id, book_id, kind, body, page, insert_time, update_time`
+
+func scanIntoBookNote(s scanner, note *BookNote) error {
+	var page *int32
+	err := s.Scan(&note.ID, &note.BookID, &note.Kind, &note.Body, &page, &note.InsertTime, &note.UpdateTime)
+	if err != nil {
+		return err
+	}
+
+	if page == nil {
+		note.Page = 0
+	} else {
+		note.Page = *page
+	}
+
+	return nil
+}
+
+func scanRowsIntoBookNotes(rows pgx.Rows) ([]*BookNote, error) {
+	var notes []*BookNote
+	for rows.Next() {
+		var note BookNote
+		err := scanIntoBookNote(rows, &note)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		notes = append(notes, &note)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return notes, nil
+}
+
+func GetBookNote(ctx context.Context, db dbconn, noteID int64) (*BookNote, error) {
+	var note BookNote
+	err := scanIntoBookNote(
+		db.QueryRow(ctx, "select "+bookNoteColumns+" from book_notes where id=$1", noteID),
+		&note,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &NotFoundError{target: fmt.Sprintf("book note id=%d", noteID)}
+		}
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// GetBookNotes returns the notes of the book specified by bookID in the order they were written.
+func GetBookNotes(ctx context.Context, db dbconn, bookID int64) ([]*BookNote, error) {
+	rows, err := db.Query(ctx, "select "+bookNoteColumns+" from book_notes where book_id=$1 order by insert_time, id", bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowsIntoBookNotes(rows)
+}
+
+// GetBookNotesByUser returns the notes of every book of userID keyed by book ID. Notes are in the order they were
+// written.
+func GetBookNotesByUser(ctx context.Context, db dbconn, userID int64) (map[int64][]*BookNote, error) {
+	rows, err := db.Query(ctx, `select `+bookNoteColumns+`
+from book_notes
+where book_id in (select id from books where user_id=$1)
+order by insert_time, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := scanRowsIntoBookNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	notesByBook := make(map[int64][]*BookNote)
+	for _, note := range notes {
+		notesByBook[note.BookID] = append(notesByBook[note.BookID], note)
+	}
+
+	return notesByBook, nil
+}
@@ -0,0 +1,77 @@
+package data_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookNoteValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		note  data.BookNote
+		field string // empty if valid
+	}{
+		{data.BookNote{Kind: data.BookNoteKindNote, Body: "Satan is the most interesting character."}, ""},
+		{data.BookNote{Kind: data.BookNoteKindQuote, Body: "Better to reign in Hell, than serve in Heaven.", Page: 12}, ""},
+		{data.BookNote{Kind: data.BookNoteKindNote, Body: " "}, "body"},
+		{data.BookNote{Kind: "highlight", Body: "Something"}, "kind"},
+		{data.BookNote{Kind: data.BookNoteKindQuote, Body: "Something", Page: -1}, "page"},
+	}
+
+	for i, tt := range tests {
+		tt.note.Normalize()
+		verrs := tt.note.Validate()
+		if tt.field == "" {
+			require.Nilf(t, verrs, "%d", i)
+		} else {
+			require.NotEmptyf(t, verrs.Get(tt.field), "%d", i)
+		}
+	}
+}
+
+func TestCreateBookNoteDoesNotChangeBookUpdateTime(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, os.Getenv("BOOKLOG_TEST_DB_CONN_STRING"))
+	require.NoError(t, err)
+	defer closeConn(t, conn)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, "insert into users(username, password_digest) values('test', 'x') returning id").Scan(&userID)
+	require.NoError(t, err)
+
+	updateTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var bookID int64
+	err = tx.QueryRow(ctx,
+		`with work as (insert into works(user_id, title, author) values($1, $2, $3) returning id)
+insert into books(user_id, work_id, title, author, finish_date, format, update_time) select $1, work.id, $2, $3, $4, $5, $6 from work returning id`,
+		userID, "Paradise Lost", "John Milton", time.Now(), "book", updateTime,
+	).Scan(&bookID)
+	require.NoError(t, err)
+
+	_, err = data.CreateBookNote(ctx, tx, data.BookNote{BookID: bookID, Kind: data.BookNoteKindQuote, Body: "Better to reign in Hell, than serve in Heaven."})
+	require.NoError(t, err)
+
+	book, err := data.GetBook(ctx, tx, bookID)
+	require.NoError(t, err)
+	require.True(t, updateTime.Equal(book.UpdateTime))
+
+	books, err := data.SearchBooks(ctx, tx, userID, "reign")
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	require.Equal(t, bookID, books[0].ID)
+}
@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// Book sort orders.
+const (
+	BookSortFinishDate = "finish_date" // newest first
+	BookSortTitle      = "title"       // A to Z
+	BookSortAuthor     = "author"      // A to Z
+	BookSortInsertTime = "insert_time" // newest first
+)
+
+// BookSorts lists every book sort order.
+var BookSorts = []string{BookSortFinishDate, BookSortTitle, BookSortAuthor, BookSortInsertTime}
+
+// bookSortKeys defines how each sort order is computed. Each sort key expression is paired with the book id so every
+// row has a unique position for keyset pagination.
+var bookSortKeys = map[string]struct {
+	expr      string // sort key expression
+	paramType string // type to cast the cursor sort key text to
+	desc      bool
+}{
+	BookSortFinishDate: {"coalesce(finish_date, '-infinity'::date)", "date", true},
+	BookSortTitle:      {"lower(title)", "text", false},
+	BookSortAuthor:     {"lower(author)", "text", false},
+	BookSortInsertTime: {"insert_time", "timestamptz", true},
+}
+
+// BookFilter restricts the books in a BookPageQuery. Zero value fields do not restrict.
+type BookFilter struct {
+	Author       string // matches the whole author or the name or alias of a linked author ignoring case and whitespace
+	Format       string
+	Location     string // matched ignoring case and whitespace
+	FinishedFrom time.Time
+	FinishedTo   time.Time
+}
+
+// BookPageQuery selects a page of books.
+type BookPageQuery struct {
+	UserID int64
+	Status string // books of all statuses if empty
+	Filter BookFilter
+	Sort   string // BookSortFinishDate if empty
+	After  string // NextCursor of the previous page; empty for the first page
+	Limit  int
+}
+
+// BookPage is a page of books.
+type BookPage struct {
+	Books      []*Book
+	NextCursor string // empty if this is the last page
+}
+
+// GetBookPage returns a page of books using keyset pagination. It returns a *NotFoundError if q.Sort or q.After is
+// invalid.
+func GetBookPage(ctx context.Context, db dbconn, q BookPageQuery) (*BookPage, error) {
+	if q.Sort == "" {
+		q.Sort = BookSortFinishDate
+	}
+	sortKey, ok := bookSortKeys[q.Sort]
+	if !ok {
+		return nil, &NotFoundError{target: fmt.Sprintf("book sort %q", q.Sort)}
+	}
+
+	direction, comparison := "asc", ">"
+	if sortKey.desc {
+		direction, comparison = "desc", "<"
+	}
+
+	args := []interface{}{q.UserID}
+	where := "user_id=$1"
+	if q.Status != "" {
+		args = append(args, q.Status)
+		where += fmt.Sprintf(" and status=$%d", len(args))
+	}
+	if q.Filter.Author != "" {
+		args = append(args, q.Filter.Author)
+		where += fmt.Sprintf(` and (normalize_book_text(author)=normalize_book_text($%[1]d) or exists(
+	select 1
+	from book_authors
+		join authors on book_authors.author_id=authors.id
+		left join author_aliases on author_aliases.author_id=authors.id
+	where book_authors.book_id=books.id
+		and (normalize_book_text(authors.name)=normalize_book_text($%[1]d) or normalize_book_text(author_aliases.name)=normalize_book_text($%[1]d))
+))`, len(args))
+	}
+	if q.Filter.Format != "" {
+		args = append(args, q.Filter.Format)
+		where += fmt.Sprintf(" and format=$%d", len(args))
+	}
+	if q.Filter.Location != "" {
+		args = append(args, q.Filter.Location)
+		where += fmt.Sprintf(" and normalize_book_text(location)=normalize_book_text($%d)", len(args))
+	}
+	if !q.Filter.FinishedFrom.IsZero() {
+		args = append(args, q.Filter.FinishedFrom)
+		where += fmt.Sprintf(" and finish_date >= $%d::date", len(args))
+	}
+	if !q.Filter.FinishedTo.IsZero() {
+		args = append(args, q.Filter.FinishedTo)
+		where += fmt.Sprintf(" and finish_date <= $%d::date", len(args))
+	}
+	if q.After != "" {
+		afterKey, afterID, err := decodeBookCursor(q.After, sortKey.paramType)
+		if err != nil {
+			return nil, &NotFoundError{target: "book page"}
+		}
+		args = append(args, afterKey, afterID)
+		where += fmt.Sprintf(" and (%s, id) %s ($%d::%s, $%d)", sortKey.expr, comparison, len(args)-1, sortKey.paramType, len(args))
+	}
+	args = append(args, q.Limit+1)
+
+	rows, err := db.Query(ctx, fmt.Sprintf(`select %s, %s::text
+from books
+where %s
+order by %s %s, id %s
+limit $%d`, bookColumns, sortKey.expr, where, sortKey.expr, direction, direction, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &BookPage{}
+	var lastKey string
+	for rows.Next() {
+		if len(page.Books) == q.Limit {
+			page.NextCursor = encodeBookCursor(lastKey, page.Books[len(page.Books)-1].ID)
+			break
+		}
+
+		var book Book
+		err := ScanIntoBook(sortKeyScanner{rows: rows, sortKey: &lastKey}, &book)
+		if err != nil {
+			return nil, err
+		}
+		page.Books = append(page.Books, &book)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return page, nil
+}
+
+// sortKeyScanner scans the book columns followed by the sort key column.
+type sortKeyScanner struct {
+	rows    pgx.Rows
+	sortKey *string
+}
+
+func (s sortKeyScanner) Scan(dest ...interface{}) error {
+	return s.rows.Scan(append(dest, s.sortKey)...)
+}
+
+// encodeBookCursor encodes the position of a book in a sort order.
+func encodeBookCursor(sortKey string, id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10) + ":" + sortKey))
+}
+
+// decodeBookCursor decodes a cursor from encodeBookCursor. It returns an error if the sort key cannot be cast to
+// paramType so an invalid cursor is rejected before it reaches the database.
+func decodeBookCursor(cursor string, paramType string) (string, int64, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, err
+	}
+
+	parts := strings.SplitN(string(buf), ":", 2)
+	if len(parts) != 2 {
+		return "", 0, errors.New("invalid cursor")
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", 0, err
+	}
+
+	err = validateBookSortKey(parts[1], paramType)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return parts[1], id, nil
+}
+
+// bookSortKeyTimestamptzLayouts are the text formats of a timestamptz in the ISO date style. The time zone offset
+// includes minutes and seconds only when they are not zero.
+var bookSortKeyTimestamptzLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+}
+
+// validateBookSortKey returns an error if sortKey is not the text format of paramType.
+func validateBookSortKey(sortKey string, paramType string) error {
+	switch paramType {
+	case "date":
+		if sortKey == "-infinity" {
+			return nil
+		}
+		_, err := time.Parse("2006-01-02", sortKey)
+		return err
+	case "timestamptz":
+		for _, layout := range bookSortKeyTimestamptzLayouts {
+			if _, err := time.Parse(layout, sortKey); err == nil {
+				return nil
+			}
+		}
+		return errors.Errorf("invalid timestamptz %q", sortKey)
+	default:
+		return nil
+	}
+}
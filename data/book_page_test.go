@@ -0,0 +1,26 @@
+package data_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+	errors "golang.org/x/xerrors"
+)
+
+func TestGetBookPageInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, q := range []data.BookPageQuery{
+		{UserID: 1, Sort: "rating", Limit: 10},
+		{UserID: 1, Sort: data.BookSortTitle, After: "not a cursor", Limit: 10},
+		{UserID: 1, Sort: data.BookSortFinishDate, After: base64.RawURLEncoding.EncodeToString([]byte("1:garbage")), Limit: 10},
+		{UserID: 1, Sort: data.BookSortInsertTime, After: base64.RawURLEncoding.EncodeToString([]byte("1:2019-06-17")), Limit: 10},
+	} {
+		_, err := data.GetBookPage(context.Background(), nil, q)
+		var nfErr *data.NotFoundError
+		require.True(t, errors.As(err, &nfErr), "%v", q)
+	}
+}
@@ -37,7 +37,8 @@ func TestDeleteBookSuccess(t *testing.T) {
 
 	var bookID int64
 	err = tx.QueryRow(ctx,
-		"insert into books(user_id, title, author, finish_date, format) values($1, $2, $3, $4, $5) returning id",
+		`with work as (insert into works(user_id, title, author) values($1, $2, $3) returning id)
+insert into books(user_id, work_id, title, author, finish_date, format) select $1, work.id, $2, $3, $4, $5 from work returning id`,
 		userID, "Paradise Lost", "John Milton", time.Now(), "book",
 	).Scan(&bookID)
 	require.NoError(t, err)
@@ -50,6 +51,12 @@ func TestDeleteBookSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	require.EqualValues(t, 0, bookCount)
+
+	var workCount int64
+	err = tx.QueryRow(ctx, "select count(*) from works where user_id=$1", userID).Scan(&workCount)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 0, workCount)
 }
 
 func TestDeleteBookMissingBookID(t *testing.T) {
@@ -72,7 +79,8 @@ func TestDeleteBookMissingBookID(t *testing.T) {
 
 	var bookID int64
 	err = tx.QueryRow(ctx,
-		"insert into books(user_id, title, author, finish_date, format) values($1, $2, $3, $4, $5) returning id",
+		`with work as (insert into works(user_id, title, author) values($1, $2, $3) returning id)
+insert into books(user_id, work_id, title, author, finish_date, format) select $1, work.id, $2, $3, $4, $5 from work returning id`,
 		userID, "Paradise Lost", "John Milton", time.Now(), "book",
 	).Scan(&bookID)
 	require.NoError(t, err)
@@ -87,3 +95,151 @@ func TestDeleteBookMissingBookID(t *testing.T) {
 
 	require.EqualValues(t, 1, bookCount)
 }
+
+func TestBookValidateRating(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rating float64
+		valid  bool
+	}{
+		{0, true},
+		{1, true},
+		{3.5, true},
+		{5, true},
+		{0.5, false},
+		{4.25, false},
+		{5.5, false},
+	}
+
+	for _, tt := range tests {
+		book := data.Book{Title: "Paradise Lost", Author: "John Milton", Status: data.BookStatusFinished, FinishDate: time.Now(), Format: "text", Rating: tt.rating}
+		verrs := book.Validate()
+		if tt.valid {
+			require.Nil(t, verrs, "rating %v", tt.rating)
+		} else {
+			require.NotEmpty(t, verrs.Get("rating"), "rating %v", tt.rating)
+		}
+	}
+}
+
+func TestBookValidateAudioMinutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format       string
+		audioMinutes int32
+		valid        bool
+	}{
+		{"audio", 0, true},
+		{"audio", 450, true},
+		{"audio", -1, false},
+		{"text", 0, true},
+		{"text", 450, false},
+	}
+
+	for _, tt := range tests {
+		book := data.Book{Title: "Paradise Lost", Author: "John Milton", Status: data.BookStatusFinished, FinishDate: time.Now(), Format: tt.format, AudioMinutes: tt.audioMinutes}
+		verrs := book.Validate()
+		if tt.valid {
+			require.Nil(t, verrs, "%s %v", tt.format, tt.audioMinutes)
+		} else {
+			require.NotEmpty(t, verrs.Get("audioMinutes"), "%s %v", tt.format, tt.audioMinutes)
+		}
+	}
+}
+
+func TestBookValidateSeriesPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		series         string
+		seriesPosition float64
+		valid          bool
+	}{
+		{"", 0, true},
+		{"Discworld", 0, true},
+		{"Discworld", 4, true},
+		{"Discworld", 1.5, true},
+		{"Discworld", 1.25, false},
+		{"Discworld", -1, false},
+		{"", 4, false},
+	}
+
+	for _, tt := range tests {
+		book := data.Book{Title: "Mort", Author: "Terry Pratchett", Status: data.BookStatusFinished, FinishDate: time.Now(), Format: "text", Series: tt.series, SeriesPosition: tt.seriesPosition}
+		verrs := book.Validate()
+		if tt.valid {
+			require.Nil(t, verrs, "%q %v", tt.series, tt.seriesPosition)
+		} else {
+			require.NotEmpty(t, verrs.Get("seriesPosition"), "%q %v", tt.series, tt.seriesPosition)
+		}
+	}
+}
+
+func TestBookReadingDays(t *testing.T) {
+	t.Parallel()
+
+	book := data.Book{FinishDate: time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)}
+	require.Equal(t, 0, book.ReadingDays())
+
+	book.StartDate = time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 1, book.ReadingDays())
+
+	book.StartDate = time.Date(2019, 2, 25, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 14, book.ReadingDays())
+}
+
+func TestUpdateBookUpdatesEveryReadingOfTheWork(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, os.Getenv("BOOKLOG_TEST_DB_CONN_STRING"))
+	require.NoError(t, err)
+	defer closeConn(t, conn)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, "insert into users(username, password_digest) values('test', 'x') returning id").Scan(&userID)
+	require.NoError(t, err)
+
+	var readings []*data.Book
+	for _, finishDate := range []time.Time{time.Date(2005, 7, 2, 0, 0, 0, 0, time.UTC), time.Date(2019, 6, 17, 0, 0, 0, 0, time.UTC)} {
+		book, err := data.CreateBook(ctx, tx, data.Book{
+			UserID:     userID,
+			Title:      "Paradise Lost",
+			Author:     "John Milton",
+			Status:     data.BookStatusFinished,
+			FinishDate: finishDate,
+			Format:     "text",
+		})
+		require.NoError(t, err)
+		readings = append(readings, book)
+	}
+	require.Equal(t, readings[0].WorkID, readings[1].WorkID)
+
+	edited := *readings[1]
+	edited.Title = "Paradise Lost: A Poem in Twelve Books"
+	edited.PageCount = 453
+	edited.Tags = []string{"poetry"}
+	edited.Location = "Library"
+	err = data.UpdateBook(ctx, tx, edited)
+	require.NoError(t, err)
+
+	other, err := data.GetBook(ctx, tx, readings[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, readings[0].WorkID, other.WorkID)
+	require.Equal(t, "Paradise Lost: A Poem in Twelve Books", other.Title)
+	require.EqualValues(t, 453, other.PageCount)
+	require.Equal(t, []string{"poetry"}, other.Tags)
+	require.Equal(t, "", other.Location)
+
+	got, err := data.GetReadings(ctx, tx, readings[0].WorkID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
@@ -0,0 +1,79 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// ChartShareToken lets the charts of its user be viewed without logging in so they can be embedded in other sites. A
+// user has at most one. Only a digest of the token is stored so the token itself is only available when it is created.
+type ChartShareToken struct {
+	UserID     int64
+	InsertTime time.Time
+}
+
+// CreateChartShareToken creates a chart share token for the user replacing any existing token. It returns the token
+// which must be shown to the user immediately as it cannot be retrieved later.
+func CreateChartShareToken(ctx context.Context, db dbconn, userID int64) (string, *ChartShareToken, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	shareToken := &ChartShareToken{UserID: userID}
+	err = db.QueryRow(ctx, `insert into chart_share_tokens(user_id, token_digest) values($1, $2)
+on conflict (user_id) do update set token_digest=excluded.token_digest, insert_time=now()
+returning insert_time`,
+		userID, digestToken(token),
+	).Scan(&shareToken.InsertTime)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, shareToken, nil
+}
+
+// GetChartShareToken returns the chart share token of the user. It returns a NotFoundError if the user has not
+// created one.
+func GetChartShareToken(ctx context.Context, db dbconn, userID int64) (*ChartShareToken, error) {
+	shareToken := ChartShareToken{UserID: userID}
+	err := db.QueryRow(ctx, "select insert_time from chart_share_tokens where user_id=$1", userID).Scan(&shareToken.InsertTime)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &NotFoundError{target: fmt.Sprintf("chart share token user_id=%d", userID)}
+		}
+		return nil, err
+	}
+
+	return &shareToken, nil
+}
+
+// DeleteChartShareToken deletes the chart share token of the user so its shared chart URLs stop working.
+func DeleteChartShareToken(ctx context.Context, db dbconn, userID int64) error {
+	_, err := db.Exec(ctx, "delete from chart_share_tokens where user_id=$1", userID)
+	return err
+}
+
+// GetUserMinByChartShareToken returns the user whose charts token shares. It returns a NotFoundError if the token is
+// not valid.
+func GetUserMinByChartShareToken(ctx context.Context, db dbconn, token string) (*UserMin, error) {
+	var user UserMin
+	err := db.QueryRow(ctx, `select users.id, users.username
+from chart_share_tokens
+	join users on chart_share_tokens.user_id=users.id
+where chart_share_tokens.token_digest=$1`,
+		digestToken(token),
+	).Scan(&user.ID, &user.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &NotFoundError{target: "chart share token"}
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,59 @@
+package data_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	errors "golang.org/x/xerrors"
+)
+
+func TestChartShareTokenLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, os.Getenv("BOOKLOG_TEST_DB_CONN_STRING"))
+	require.NoError(t, err)
+	defer closeConn(t, conn)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	err = tx.QueryRow(ctx, "insert into users(username, password_digest) values('test', 'x') returning id").Scan(&userID)
+	require.NoError(t, err)
+
+	var nfErr *data.NotFoundError
+
+	_, err = data.GetChartShareToken(ctx, tx, userID)
+	require.True(t, errors.As(err, &nfErr))
+
+	oldToken, _, err := data.CreateChartShareToken(ctx, tx, userID)
+	require.NoError(t, err)
+
+	user, err := data.GetUserMinByChartShareToken(ctx, tx, oldToken)
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+
+	newToken, _, err := data.CreateChartShareToken(ctx, tx, userID)
+	require.NoError(t, err)
+
+	_, err = data.GetUserMinByChartShareToken(ctx, tx, oldToken)
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = data.GetChartShareToken(ctx, tx, userID)
+	require.NoError(t, err)
+
+	err = data.DeleteChartShareToken(ctx, tx, userID)
+	require.NoError(t, err)
+
+	_, err = data.GetUserMinByChartShareToken(ctx, tx, newToken)
+	require.True(t, errors.As(err, &nfErr))
+}
@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -32,3 +33,35 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("not found: %s", e.target)
 }
+
+// nullString returns nil if s is empty. Otherwise it returns &s.
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullFloat64 returns nil if n is 0. Otherwise it returns &n.
+func nullFloat64(n float64) *float64 {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+// nullInt32 returns nil if n is 0. Otherwise it returns &n.
+func nullInt32(n int32) *int32 {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+// nullDate returns nil if t is the zero time. Otherwise it returns &t.
+func nullDate(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
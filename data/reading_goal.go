@@ -0,0 +1,67 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// ReadingGoal is the number of books a user intends to finish in a year.
+type ReadingGoal struct {
+	UserID int64
+	Year   int
+	Target int32
+}
+
+func (goal *ReadingGoal) Validate() validate.Errors {
+	v := validate.New()
+
+	if goal.Year < 1 || goal.Year > 9999 {
+		v.Add("year", errors.New("is not a valid year"))
+	}
+
+	if goal.Target < 1 {
+		v.Add("target", errors.New("must be at least 1"))
+	}
+
+	if v.Err() != nil {
+		return v.Err().(validate.Errors)
+	}
+
+	return nil
+}
+
+// SetReadingGoal creates or replaces the reading goal for goal.UserID and goal.Year.
+func SetReadingGoal(ctx context.Context, db dbconn, goal ReadingGoal) error {
+	if verrs := goal.Validate(); verrs != nil {
+		return verrs
+	}
+
+	_, err := db.Exec(ctx, `insert into reading_goals(user_id, year, target) values($1, $2, $3)
+on conflict (user_id, year) do update set target=excluded.target`,
+		goal.UserID, goal.Year, goal.Target)
+	return err
+}
+
+// DeleteReadingGoal removes the reading goal for userID and year if there is one.
+func DeleteReadingGoal(ctx context.Context, db dbconn, userID int64, year int) error {
+	_, err := db.Exec(ctx, "delete from reading_goals where user_id=$1 and year=$2", userID, year)
+	return err
+}
+
+// GetReadingGoal returns the reading goal for userID and year. It returns a *NotFoundError if there is no goal.
+func GetReadingGoal(ctx context.Context, db dbconn, userID int64, year int) (*ReadingGoal, error) {
+	goal := ReadingGoal{UserID: userID, Year: year}
+	err := db.QueryRow(ctx, "select target from reading_goals where user_id=$1 and year=$2", userID, year).Scan(&goal.Target)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &NotFoundError{target: fmt.Sprintf("reading goal year=%d", year)}
+		}
+		return nil, err
+	}
+
+	return &goal, nil
+}
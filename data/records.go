@@ -0,0 +1,120 @@
+package data
+
+import (
+	"time"
+)
+
+// ReadingRecords are personal records computed from finished books.
+type ReadingRecords struct {
+	LongestMonthStreak int // most consecutive months with at least one finished book
+	CurrentMonthStreak int
+	LongestWeekStreak  int // most consecutive Monday to Sunday weeks with at least one finished book
+	CurrentWeekStreak  int
+	BestMonth          BooksPerTimeItem // month with the most finished books; zero if no books
+	BestYear           BooksPerTimeItem // year with the most finished books; zero if no books
+	FastestRead        *Book            // fewest reading days; nil if no finished book has a start date
+}
+
+// ComputeReadingRecords computes the records for the finished books as of now. Books that are not finished are
+// ignored. A current streak includes the current month or week if it already has a finished book; otherwise it ends
+// with the previous month or week so a streak is not broken until the period is over. Ties for best month and year go
+// to the earliest and ties for fastest read go to the earliest finished.
+func ComputeReadingRecords(books []*Book, now time.Time) *ReadingRecords {
+	records := &ReadingRecords{}
+
+	months := make(map[int]int32)
+	weeks := make(map[int]struct{})
+	years := make(map[int]int32)
+	for _, book := range books {
+		if book.Status != BookStatusFinished || book.FinishDate.IsZero() {
+			continue
+		}
+
+		months[monthIndex(book.FinishDate)]++
+		weeks[weekIndex(book.FinishDate)] = struct{}{}
+		years[book.FinishDate.Year()]++
+
+		if book.ReadingDays() > 0 {
+			if records.FastestRead == nil ||
+				book.ReadingDays() < records.FastestRead.ReadingDays() ||
+				(book.ReadingDays() == records.FastestRead.ReadingDays() && book.FinishDate.Before(records.FastestRead.FinishDate)) {
+				records.FastestRead = book
+			}
+		}
+	}
+
+	monthSet := make(map[int]struct{}, len(months))
+	for m, count := range months {
+		monthSet[m] = struct{}{}
+		if count > records.BestMonth.Count || (count == records.BestMonth.Count && m < monthIndex(records.BestMonth.Time)) {
+			records.BestMonth = BooksPerTimeItem{Time: time.Date(m/12, time.Month(m%12+1), 1, 0, 0, 0, 0, time.UTC), Count: count}
+		}
+	}
+
+	for y, count := range years {
+		if count > records.BestYear.Count || (count == records.BestYear.Count && y < records.BestYear.Time.Year()) {
+			records.BestYear = BooksPerTimeItem{Time: time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC), Count: count}
+		}
+	}
+
+	records.LongestMonthStreak = longestStreak(monthSet)
+	records.CurrentMonthStreak = currentStreak(monthSet, monthIndex(now))
+	records.LongestWeekStreak = longestStreak(weeks)
+	records.CurrentWeekStreak = currentStreak(weeks, weekIndex(now))
+
+	return records
+}
+
+// monthIndex numbers months consecutively.
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// weekIndex numbers Monday to Sunday weeks consecutively.
+func weekIndex(t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	daysSinceMonday := (int(day.Weekday()) + 6) % 7
+	monday := day.AddDate(0, 0, -daysSinceMonday)
+	// January 5, 1970 was a Monday.
+	return int(monday.Sub(time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)).Hours()) / 24 / 7
+}
+
+// longestStreak returns the length of the longest run of consecutive indexes in set.
+func longestStreak(set map[int]struct{}) int {
+	longest := 0
+	for i := range set {
+		// Only count from the start of each run.
+		if _, ok := set[i-1]; ok {
+			continue
+		}
+
+		n := 1
+		for {
+			if _, ok := set[i+n]; !ok {
+				break
+			}
+			n++
+		}
+		if n > longest {
+			longest = n
+		}
+	}
+
+	return longest
+}
+
+// currentStreak returns the length of the run of consecutive indexes in set ending at current, or ending at
+// current-1 if current is not in set.
+func currentStreak(set map[int]struct{}, current int) int {
+	if _, ok := set[current]; !ok {
+		current--
+	}
+
+	n := 0
+	for {
+		if _, ok := set[current-n]; !ok {
+			return n
+		}
+		n++
+	}
+}
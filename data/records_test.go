@@ -0,0 +1,79 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func finishedBook(title string, start, finish string) *data.Book {
+	book := &data.Book{Title: title, Status: data.BookStatusFinished}
+	if start != "" {
+		book.StartDate, _ = time.Parse("2006-01-02", start)
+	}
+	book.FinishDate, _ = time.Parse("2006-01-02", finish)
+	return book
+}
+
+func TestComputeReadingRecords(t *testing.T) {
+	t.Parallel()
+
+	books := []*data.Book{
+		finishedBook("A", "", "2018-11-20"),
+		finishedBook("B", "2018-12-01", "2018-12-10"),
+		finishedBook("C", "", "2019-01-02"),
+		finishedBook("D", "", "2019-01-03"),
+		finishedBook("E", "2019-01-20", "2019-01-22"),
+		finishedBook("F", "", "2019-02-11"),
+		finishedBook("G", "2019-05-05", "2019-05-07"),
+		finishedBook("H", "", "2019-06-14"),
+		{Title: "Not finished", Status: data.BookStatusReading, StartDate: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	records := data.ComputeReadingRecords(books, time.Date(2019, 7, 4, 0, 0, 0, 0, time.UTC))
+
+	// November 2018 through February 2019.
+	require.Equal(t, 4, records.LongestMonthStreak)
+	// July has no finished book yet so the streak is May and June.
+	require.Equal(t, 2, records.CurrentMonthStreak)
+
+	// January 2 and 3 are in the same week. The longest run is a single week.
+	require.Equal(t, 1, records.LongestWeekStreak)
+	require.Equal(t, 0, records.CurrentWeekStreak)
+
+	require.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), records.BestMonth.Time)
+	require.EqualValues(t, 3, records.BestMonth.Count)
+	require.Equal(t, 2019, records.BestYear.Time.Year())
+	require.EqualValues(t, 6, records.BestYear.Count)
+
+	// E and G both took 3 days. E finished first.
+	require.Equal(t, "E", records.FastestRead.Title)
+}
+
+func TestComputeReadingRecordsWeekStreaks(t *testing.T) {
+	t.Parallel()
+
+	// Weeks starting Monday July 1, 8, and 15, 2019 each have a book. Sunday July 7 ends the first week.
+	books := []*data.Book{
+		finishedBook("A", "", "2019-07-07"),
+		finishedBook("B", "", "2019-07-08"),
+		finishedBook("C", "", "2019-07-16"),
+	}
+
+	records := data.ComputeReadingRecords(books, time.Date(2019, 7, 18, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, 3, records.LongestWeekStreak)
+	require.Equal(t, 3, records.CurrentWeekStreak)
+	require.Equal(t, 1, records.CurrentMonthStreak)
+}
+
+func TestComputeReadingRecordsWithoutBooks(t *testing.T) {
+	t.Parallel()
+
+	records := data.ComputeReadingRecords(nil, time.Now())
+	require.Equal(t, 0, records.LongestMonthStreak)
+	require.Equal(t, 0, records.CurrentMonthStreak)
+	require.EqualValues(t, 0, records.BestYear.Count)
+	require.Nil(t, records.FastestRead)
+}
@@ -0,0 +1,28 @@
+package data
+
+import "context"
+
+// SearchBooks returns the books of the user that match the full-text search query. Title and author matches rank
+// above location matches which rank above review and note matches. Books with the same rank are ordered by most recently
+// finished.
+func SearchBooks(ctx context.Context, db dbconn, userID int64, query string) ([]*Book, error) {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books, plainto_tsquery('english', $2) query
+where user_id=$1
+	and (
+		books.search_vector @@ query
+		or exists(select 1 from book_notes where book_notes.book_id=books.id and book_notes.search_vector @@ query)
+	)
+order by ts_rank(
+		books.search_vector || setweight(to_tsvector('english', coalesce((select string_agg(body, ' ') from book_notes where book_notes.book_id=books.id), '')), 'D'),
+		query
+	) desc,
+	finish_date desc nulls first,
+	insert_time desc`,
+		userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRowsIntoBooks(rows)
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// SeriesEntry is a position in a series and the books at that position.
+type SeriesEntry struct {
+	Position float64
+	Books    []*Book
+}
+
+// Read returns true if any book of the entry is finished.
+func (e SeriesEntry) Read() bool {
+	for _, book := range e.Books {
+		if book.Status == BookStatusFinished {
+			return true
+		}
+	}
+	return false
+}
+
+// Series is the books of a series in reading order.
+type Series struct {
+	Name       string
+	Entries    []SeriesEntry // numbered books by position
+	Unnumbered []*Book       // books without a series position
+	Gaps       []int         // whole positions up to the last entry that have no books
+}
+
+// ReadCount returns the number of entries that have been read.
+func (s *Series) ReadCount() int {
+	n := 0
+	for _, e := range s.Entries {
+		if e.Read() {
+			n++
+		}
+	}
+	return n
+}
+
+// NewSeries groups books into the entries of the series name. Books at the same position, such as a reread or a
+// second format, share an entry.
+func NewSeries(name string, books []*Book) *Series {
+	series := &Series{Name: name}
+
+	entryIndexes := make(map[float64]int)
+	for _, book := range books {
+		if book.SeriesPosition == 0 {
+			series.Unnumbered = append(series.Unnumbered, book)
+			continue
+		}
+
+		i, ok := entryIndexes[book.SeriesPosition]
+		if !ok {
+			i = len(series.Entries)
+			entryIndexes[book.SeriesPosition] = i
+			series.Entries = append(series.Entries, SeriesEntry{Position: book.SeriesPosition})
+		}
+		series.Entries[i].Books = append(series.Entries[i].Books, book)
+	}
+
+	sort.Slice(series.Entries, func(i, j int) bool {
+		return series.Entries[i].Position < series.Entries[j].Position
+	})
+
+	if len(series.Entries) > 0 {
+		last := int(math.Floor(series.Entries[len(series.Entries)-1].Position))
+		for n := 1; n <= last; n++ {
+			if _, ok := entryIndexes[float64(n)]; !ok {
+				series.Gaps = append(series.Gaps, n)
+			}
+		}
+	}
+
+	return series
+}
+
+// SeriesListItem is a series and the number of its books.
+type SeriesListItem struct {
+	Name      string
+	BookCount int32
+	ReadCount int32 // finished books
+}
+
+// GetAllSeries returns every series of userID ordered by name. Series names are matched ignoring case and whitespace.
+func GetAllSeries(ctx context.Context, db dbconn, userID int64) ([]SeriesListItem, error) {
+	rows, err := db.Query(ctx, `select min(series), count(*), count(*) filter (where status='finished')
+from books
+where user_id=$1 and series is not null
+group by normalize_book_text(series)
+order by 1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []SeriesListItem
+	for rows.Next() {
+		var item SeriesListItem
+		rows.Scan(&item.Name, &item.BookCount, &item.ReadCount)
+		series = append(series, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return series, nil
+}
+
+// GetBooksBySeries returns all books of the user in the series name regardless of status. Series names are matched
+// ignoring case and whitespace. Books are ordered by series position.
+func GetBooksBySeries(ctx context.Context, db dbconn, userID int64, name string) ([]*Book, error) {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books
+where user_id=$1 and normalize_book_text(series)=normalize_book_text($2)
+order by series_position nulls last, finish_date nulls last, insert_time`,
+		userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRowsIntoBooks(rows)
+}
@@ -0,0 +1,52 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSeries(t *testing.T) {
+	t.Parallel()
+
+	book := func(title string, position float64, status string) *data.Book {
+		return &data.Book{Title: title, Series: "Discworld", SeriesPosition: position, Status: status}
+	}
+
+	books := []*data.Book{
+		book("Mort", 4, data.BookStatusFinished),
+		book("The Colour of Magic", 1, data.BookStatusFinished),
+		book("The Colour of Magic", 1, data.BookStatusWantToRead),
+		book("Troll Bridge", 1.5, data.BookStatusFinished),
+		book("Equal Rites", 3, data.BookStatusWantToRead),
+		book("The Art of Discworld", 0, data.BookStatusFinished),
+	}
+
+	series := data.NewSeries("Discworld", books)
+	require.Equal(t, "Discworld", series.Name)
+	require.Len(t, series.Entries, 4)
+
+	positions := make([]float64, len(series.Entries))
+	for i, e := range series.Entries {
+		positions[i] = e.Position
+	}
+	require.Equal(t, []float64{1, 1.5, 3, 4}, positions)
+	require.Len(t, series.Entries[0].Books, 2)
+	require.True(t, series.Entries[0].Read())
+	require.False(t, series.Entries[2].Read())
+	require.Equal(t, 3, series.ReadCount())
+
+	require.Equal(t, []int{2}, series.Gaps)
+	require.Len(t, series.Unnumbered, 1)
+	require.Equal(t, "The Art of Discworld", series.Unnumbered[0].Title)
+}
+
+func TestNewSeriesWithoutNumberedBooks(t *testing.T) {
+	t.Parallel()
+
+	series := data.NewSeries("Discworld", []*data.Book{{Title: "The Art of Discworld", Series: "Discworld"}})
+	require.Empty(t, series.Entries)
+	require.Empty(t, series.Gaps)
+	require.Len(t, series.Unnumbered, 1)
+}
@@ -0,0 +1,67 @@
+package data
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// NormalizeTags trims and lowercases tags and removes blank and duplicate tags. The result is sorted.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		normalized = append(normalized, t)
+	}
+	sort.Strings(normalized)
+
+	return normalized
+}
+
+// setBookTags replaces the tags of the book specified by bookID with tags. Tags that do not yet exist for the user are
+// created.
+func setBookTags(ctx context.Context, db dbconn, userID int64, bookID int64, tags []string) error {
+	if len(tags) > 0 {
+		_, err := db.Exec(ctx, "insert into tags(user_id, name) select $1, unnest($2::text[]) on conflict do nothing", userID, tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := db.Exec(ctx, "delete from book_tags where book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) > 0 {
+		_, err = db.Exec(ctx, "insert into book_tags(book_id, tag_id) select $1, id from tags where user_id=$2 and name=any($3)", bookID, userID, tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBooksByTag returns all books of the user tagged with tag regardless of status.
+func GetBooksByTag(ctx context.Context, db dbconn, userID int64, tag string) ([]*Book, error) {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books
+where user_id=$1
+	and exists(select 1 from book_tags join tags on book_tags.tag_id=tags.id where book_tags.book_id=books.id and tags.name=$2)
+order by finish_date desc nulls first, insert_time desc`,
+		userID, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRowsIntoBooks(rows)
+}
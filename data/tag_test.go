@@ -0,0 +1,15 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	t.Parallel()
+
+	tags := data.NormalizeTags([]string{" History", "fiction", "", "history ", "Work"})
+	require.Equal(t, []string{"fiction", "history", "work"}, tags)
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	errors "golang.org/x/xerrors"
+)
+
+// findOrCreateWork returns the ID of the work of userID whose title and author match title and author ignoring case and
+// whitespace. The work is created if it does not exist.
+func findOrCreateWork(ctx context.Context, db dbconn, userID int64, title string, author string) (int64, error) {
+	var workID int64
+	err := db.QueryRow(ctx, `insert into works(user_id, title, author) values($1, $2, $3)
+on conflict (user_id, normalize_book_text(title), normalize_book_text(author)) do update set title=works.title
+returning id`, userID, title, author).Scan(&workID)
+	return workID, err
+}
+
+// renameWork changes the title and author of the work specified by workID. If another work of userID already has the
+// title and author ignoring case and whitespace, the readings of workID are moved to it and workID is deleted. It
+// returns the ID of the work with the new title and author.
+func renameWork(ctx context.Context, db dbconn, userID int64, workID int64, title string, author string) (int64, error) {
+	var otherWorkID int64
+	err := db.QueryRow(ctx, `select id from works
+where user_id=$1
+	and normalize_book_text(title)=normalize_book_text($2)
+	and normalize_book_text(author)=normalize_book_text($3)
+	and id<>$4`, userID, title, author, workID).Scan(&otherWorkID)
+	if err == nil {
+		_, err = db.Exec(ctx, "update books set work_id=$1 where work_id=$2", otherWorkID, workID)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = db.Exec(ctx, "delete from works where id=$1", workID)
+		if err != nil {
+			return 0, err
+		}
+
+		return otherWorkID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+
+	_, err = db.Exec(ctx, "update works set title=$1, author=$2 where id=$3", title, author, workID)
+	return workID, err
+}
+
+// updateWorkReadings sets the fields of every reading of the work specified by workID that describe the work rather
+// than the reading to those of book. These are Title, Author, ISBN, PageCount, Series, SeriesPosition, and Tags. The
+// authors of every reading are linked again from Author.
+func updateWorkReadings(ctx context.Context, db dbconn, userID int64, workID int64, book Book) error {
+	rows, err := db.Query(ctx, "update books set title=$1, author=$2, isbn=$3, page_count=$4, series=$5, series_position=$6 where work_id=$7 returning id",
+		book.Title,
+		book.Author,
+		nullString(book.ISBN),
+		nullInt32(book.PageCount),
+		nullString(book.Series),
+		nullFloat64(book.SeriesPosition),
+		workID,
+	)
+	if err != nil {
+		return err
+	}
+
+	var bookIDs []int64
+	for rows.Next() {
+		var bookID int64
+		rows.Scan(&bookID)
+		bookIDs = append(bookIDs, bookID)
+	}
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	for _, bookID := range bookIDs {
+		err = setBookTags(ctx, db, userID, bookID, book.Tags)
+		if err != nil {
+			return err
+		}
+
+		err = setBookAuthors(ctx, db, userID, bookID, book.Author)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteWorkIfUnread deletes the work specified by workID if it no longer has any readings.
+func deleteWorkIfUnread(ctx context.Context, db dbconn, workID int64) error {
+	_, err := db.Exec(ctx, "delete from works where id=$1 and not exists(select 1 from books where work_id=$1)", workID)
+	return err
+}
+
+// GetReadings returns every reading of the work specified by workID in the order they were read. Readings that are not
+// finished are last.
+func GetReadings(ctx context.Context, db dbconn, workID int64) ([]*Book, error) {
+	rows, err := db.Query(ctx, `select `+bookColumns+`
+from books
+where work_id=$1
+order by finish_date nulls last, start_date nulls last, insert_time`,
+		workID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanRowsIntoBooks(rows)
+}
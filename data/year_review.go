@@ -0,0 +1,102 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// yearReviewTopAuthorsCount is the number of authors in a YearReview.
+const yearReviewTopAuthorsCount = 5
+
+// YearReview is a recap of the books finished in a year.
+type YearReview struct {
+	Year          int
+	BookCount     int
+	Formats       []FormatCountItem // most common first
+	LongestBook   *Book             // most pages; nil if no book has a page count
+	ShortestBook  *Book             // fewest pages; nil if no book has a page count
+	FirstBook     *Book             // nil if no books
+	LastBook      *Book             // nil if no books
+	TopAuthors    []AuthorCountItem // most books first
+	BooksPerMonth []MonthCountItem  // January through December
+}
+
+// ComputeYearReview computes the review of year from books. Books that were not finished in year are ignored. Books are
+// counted for each of their linked authors. A book without linked authors is counted for its author matched ignoring
+// case and whitespace.
+func ComputeYearReview(year int, books []*Book) *YearReview {
+	review := &YearReview{Year: year}
+	for m := time.January; m <= time.December; m++ {
+		review.BooksPerMonth = append(review.BooksPerMonth, MonthCountItem{Month: m})
+	}
+
+	formatCounts := make(map[string]int32)
+	authorCounts := make(map[string]*AuthorCountItem)
+	var authorOrder []string
+
+	for _, book := range books {
+		if book.Status != BookStatusFinished || book.FinishDate.Year() != year {
+			continue
+		}
+
+		review.BookCount++
+		review.BooksPerMonth[book.FinishDate.Month()-1].Count++
+		formatCounts[book.Format]++
+
+		authors := make([]AuthorCountItem, 0, len(book.Authors))
+		for _, a := range book.Authors {
+			authors = append(authors, AuthorCountItem{AuthorID: a.ID, Author: a.Name})
+		}
+		if len(authors) == 0 {
+			authors = append(authors, AuthorCountItem{Author: book.Author})
+		}
+		for _, a := range authors {
+			key := normalizeAuthorName(a.Author)
+			if a.AuthorID != 0 {
+				key = fmt.Sprintf("id=%d", a.AuthorID)
+			}
+			if _, ok := authorCounts[key]; !ok {
+				authorCounts[key] = &AuthorCountItem{AuthorID: a.AuthorID, Author: a.Author}
+				authorOrder = append(authorOrder, key)
+			}
+			authorCounts[key].Count++
+		}
+
+		if review.FirstBook == nil || book.FinishDate.Before(review.FirstBook.FinishDate) {
+			review.FirstBook = book
+		}
+		if review.LastBook == nil || !book.FinishDate.Before(review.LastBook.FinishDate) {
+			review.LastBook = book
+		}
+
+		if book.PageCount > 0 {
+			if review.LongestBook == nil || book.PageCount > review.LongestBook.PageCount {
+				review.LongestBook = book
+			}
+			if review.ShortestBook == nil || book.PageCount < review.ShortestBook.PageCount {
+				review.ShortestBook = book
+			}
+		}
+	}
+
+	for format, count := range formatCounts {
+		review.Formats = append(review.Formats, FormatCountItem{Format: format, Count: count})
+	}
+	sort.Slice(review.Formats, func(i, j int) bool {
+		a, b := review.Formats[i], review.Formats[j]
+		return a.Count > b.Count || (a.Count == b.Count && a.Format < b.Format)
+	})
+
+	for _, key := range authorOrder {
+		review.TopAuthors = append(review.TopAuthors, *authorCounts[key])
+	}
+	sort.SliceStable(review.TopAuthors, func(i, j int) bool {
+		return review.TopAuthors[i].Count > review.TopAuthors[j].Count
+	})
+	if len(review.TopAuthors) > yearReviewTopAuthorsCount {
+		review.TopAuthors = review.TopAuthors[:yearReviewTopAuthorsCount]
+	}
+
+	return review
+}
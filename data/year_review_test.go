@@ -0,0 +1,77 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeYearReview(t *testing.T) {
+	t.Parallel()
+
+	book := func(title, author, format string, pageCount int32, finish string) *data.Book {
+		b := finishedBook(title, "", finish)
+		b.Author = author
+		b.Format = format
+		b.PageCount = pageCount
+		return b
+	}
+
+	books := []*data.Book{
+		book("Paradise Regained", "John Milton", "text", 120, "2019-12-28"),
+		book("The Dilbert Future", "Scott Adams", "text", 0, "2019-07-10"),
+		book("Napoleon", "Adam Zamoyski", "audio", 0, "2019-06-17"),
+		book("Paradise Lost", "John  Milton", "text", 453, "2019-03-02"),
+		book("Areopagitica", "john milton", "text", 60, "2019-01-15"),
+		book("Last Year", "Scott Adams", "video", 900, "2018-12-31"),
+		{Title: "Reading", Author: "Scott Adams", Status: data.BookStatusReading, PageCount: 10},
+	}
+
+	review := data.ComputeYearReview(2019, books)
+	require.Equal(t, 2019, review.Year)
+	require.Equal(t, 5, review.BookCount)
+	require.Equal(t, []data.FormatCountItem{{"text", 4}, {"audio", 1}}, review.Formats)
+	require.Equal(t, "Paradise Lost", review.LongestBook.Title)
+	require.Equal(t, "Areopagitica", review.ShortestBook.Title)
+	require.Equal(t, "Areopagitica", review.FirstBook.Title)
+	require.Equal(t, "Paradise Regained", review.LastBook.Title)
+	require.Equal(t, []data.AuthorCountItem{{Author: "John Milton", Count: 3}, {Author: "Scott Adams", Count: 1}, {Author: "Adam Zamoyski", Count: 1}}, review.TopAuthors)
+
+	require.Len(t, review.BooksPerMonth, 12)
+	require.Equal(t, data.MonthCountItem{Month: time.January, Count: 1}, review.BooksPerMonth[0])
+	require.EqualValues(t, 0, review.BooksPerMonth[1].Count)
+	require.EqualValues(t, 1, review.BooksPerMonth[11].Count)
+}
+
+func TestComputeYearReviewLinkedAuthors(t *testing.T) {
+	t.Parallel()
+
+	tolkien := data.AuthorMin{ID: 1, Name: "J.R.R. Tolkien"}
+	christopher := data.AuthorMin{ID: 2, Name: "Christopher Tolkien"}
+
+	hobbit := finishedBook("The Hobbit", "", "2019-02-01")
+	hobbit.Author = "JRR Tolkien"
+	hobbit.Authors = []data.AuthorMin{tolkien}
+
+	silmarillion := finishedBook("The Silmarillion", "", "2019-05-01")
+	silmarillion.Author = "J.R.R. Tolkien and Christopher Tolkien"
+	silmarillion.Authors = []data.AuthorMin{tolkien, christopher}
+
+	review := data.ComputeYearReview(2019, []*data.Book{hobbit, silmarillion})
+	require.Equal(t, []data.AuthorCountItem{
+		{AuthorID: 1, Author: "J.R.R. Tolkien", Count: 2},
+		{AuthorID: 2, Author: "Christopher Tolkien", Count: 1},
+	}, review.TopAuthors)
+}
+
+func TestComputeYearReviewWithoutBooks(t *testing.T) {
+	t.Parallel()
+
+	review := data.ComputeYearReview(2019, nil)
+	require.Equal(t, 0, review.BookCount)
+	require.Nil(t, review.FirstBook)
+	require.Nil(t, review.LongestBook)
+	require.Len(t, review.BooksPerMonth, 12)
+}
@@ -0,0 +1,7 @@
+alter table books add column rating numeric(2,1) check (rating between 1 and 5 and rating * 2 = trunc(rating * 2));
+alter table books add column review text;
+
+---- create above / drop below ----
+
+alter table books drop column review;
+alter table books drop column rating;
@@ -0,0 +1,5 @@
+alter table books add column start_date date check (start_date <= finish_date);
+
+---- create above / drop below ----
+
+alter table books drop column start_date;
@@ -0,0 +1,13 @@
+alter table books add column status text not null default 'finished' check (status in ('want_to_read', 'reading', 'finished', 'abandoned'));
+alter table books alter column finish_date drop not null;
+alter table books add constraint books_finished_has_finish_date check (status <> 'finished' or finish_date is not null);
+
+create index on books (user_id, status);
+
+---- create above / drop below ----
+
+delete from books where finish_date is null;
+
+alter table books drop constraint books_finished_has_finish_date;
+alter table books alter column finish_date set not null;
+alter table books drop column status;
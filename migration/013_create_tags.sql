@@ -0,0 +1,26 @@
+create table tags (
+  id bigint primary key,
+  user_id bigint not null references users on delete cascade,
+  name text not null,
+  insert_time timestamptz not null default now(),
+  unique (user_id, name)
+);
+select set_default_to_next_duid_block('tags', 'id', 'tag_id_seq');
+
+create table book_tags (
+  book_id bigint not null references books on delete cascade,
+  tag_id bigint not null references tags on delete cascade,
+  primary key (book_id, tag_id)
+);
+
+create index on book_tags (tag_id);
+
+grant select, insert, update, delete on table tags to {{.app_user}};
+grant usage on sequence tag_id_seq to {{.app_user}};
+grant select, insert, update, delete on table book_tags to {{.app_user}};
+
+---- create above / drop below ----
+
+drop table book_tags;
+drop table tags;
+drop sequence tag_id_seq;
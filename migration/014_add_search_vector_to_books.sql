@@ -0,0 +1,32 @@
+alter table books add column search_vector tsvector;
+
+create function book_search_vector_update() returns trigger
+language plpgsql
+as $$
+  begin
+    new.search_vector =
+      setweight(to_tsvector('english', new.title), 'A') ||
+      setweight(to_tsvector('english', new.author), 'A') ||
+      setweight(to_tsvector('english', coalesce(new.location, '')), 'C') ||
+      setweight(to_tsvector('english', coalesce(new.review, '')), 'D');
+    return new;
+  end;
+$$;
+
+create trigger on_book_search_vector_update
+before insert or update on books
+for each row execute procedure book_search_vector_update();
+
+update books set search_vector =
+  setweight(to_tsvector('english', title), 'A') ||
+  setweight(to_tsvector('english', author), 'A') ||
+  setweight(to_tsvector('english', coalesce(location, '')), 'C') ||
+  setweight(to_tsvector('english', coalesce(review, '')), 'D');
+
+create index on books using gin (search_vector);
+
+---- create above / drop below ----
+
+drop trigger on_book_search_vector_update on books;
+drop function book_search_vector_update();
+alter table books drop column search_vector;
@@ -0,0 +1,19 @@
+create table api_tokens (
+  id bigint primary key,
+  user_id bigint not null references users on delete cascade,
+  name text not null,
+  token_digest bytea not null unique,
+  last_used_time timestamptz,
+  insert_time timestamptz not null default now()
+);
+select set_default_to_next_duid_block('api_tokens', 'id', 'api_token_id_seq');
+
+create index on api_tokens (user_id);
+
+grant select, insert, update, delete on table api_tokens to {{.app_user}};
+grant usage on sequence api_token_id_seq to {{.app_user}};
+
+---- create above / drop below ----
+
+drop table api_tokens;
+drop sequence api_token_id_seq;
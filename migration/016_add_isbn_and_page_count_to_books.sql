@@ -0,0 +1,7 @@
+alter table books add column isbn text check (isbn ~ '^(\d{9}[\dX]|\d{13})$');
+alter table books add column page_count integer check (page_count > 0);
+
+---- create above / drop below ----
+
+alter table books drop column page_count;
+alter table books drop column isbn;
@@ -0,0 +1,14 @@
+-- normalize_book_text normalizes titles and authors for duplicate detection.
+create function normalize_book_text(text) returns text
+immutable
+parallel safe
+language sql
+as $$
+  select lower(regexp_replace(trim($1), '\s+', ' ', 'g'));
+$$;
+
+create index on books (user_id, normalize_book_text(title), normalize_book_text(author));
+
+---- create above / drop below ----
+
+drop function normalize_book_text(text) cascade;
@@ -0,0 +1,18 @@
+create table reading_goals (
+  user_id bigint not null references users on delete cascade,
+  year smallint not null,
+  target int not null check (target > 0),
+  insert_time timestamptz not null default now(),
+  update_time timestamptz not null default now(),
+  primary key (user_id, year)
+);
+
+create trigger on_reading_goal_update
+before update on reading_goals
+for each row execute procedure timestamp_update();
+
+grant select, insert, update, delete on table reading_goals to {{.app_user}};
+
+---- create above / drop below ----
+
+drop table reading_goals;
@@ -0,0 +1,5 @@
+alter table books add column audio_minutes integer check (audio_minutes > 0);
+
+---- create above / drop below ----
+
+alter table books drop column audio_minutes;
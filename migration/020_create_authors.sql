@@ -0,0 +1,71 @@
+create table authors (
+  id bigint primary key,
+  user_id bigint not null references users on delete cascade,
+  name text not null check (name <> ''),
+  insert_time timestamptz not null default now(),
+  update_time timestamptz not null default now()
+);
+select set_default_to_next_duid_block('authors', 'id', 'author_id_seq');
+
+create unique index on authors (user_id, normalize_book_text(name));
+
+create trigger on_author_update
+before update on authors
+for each row execute procedure timestamp_update();
+
+create table author_aliases (
+  author_id bigint not null references authors on delete cascade,
+  user_id bigint not null references users on delete cascade,
+  name text not null check (name <> ''),
+  primary key (author_id, name)
+);
+
+create unique index on author_aliases (user_id, normalize_book_text(name));
+
+create table book_authors (
+  book_id bigint not null references books on delete cascade,
+  author_id bigint not null references authors on delete cascade,
+  position smallint not null,
+  primary key (book_id, author_id)
+);
+
+create index on book_authors (author_id);
+
+-- split_author_names splits the author of a book into the names of its authors. It must match
+-- data.SplitAuthorNames.
+create function split_author_names(text) returns table(name text, position bigint)
+immutable
+parallel safe
+language sql
+as $$
+  select trim(name), position
+  from regexp_split_to_table($1, '\s+(?:and|&)\s+|\s*;\s*') with ordinality as t(name, position)
+  where trim(name) <> '';
+$$;
+
+insert into authors(user_id, name)
+select distinct on (books.user_id, normalize_book_text(names.name)) books.user_id, names.name
+from books
+  cross join lateral split_author_names(books.author) names
+order by books.user_id, normalize_book_text(names.name), books.insert_time;
+
+insert into book_authors(book_id, author_id, position)
+select books.id, authors.id, min(names.position)
+from books
+  cross join lateral split_author_names(books.author) names
+  join authors on authors.user_id=books.user_id and normalize_book_text(authors.name)=normalize_book_text(names.name)
+group by books.id, authors.id;
+
+drop function split_author_names(text);
+
+grant select, insert, update, delete on table authors to {{.app_user}};
+grant usage on sequence author_id_seq to {{.app_user}};
+grant select, insert, update, delete on table author_aliases to {{.app_user}};
+grant select, insert, update, delete on table book_authors to {{.app_user}};
+
+---- create above / drop below ----
+
+drop table book_authors;
+drop table author_aliases;
+drop table authors;
+drop sequence author_id_seq;
@@ -0,0 +1,10 @@
+alter table books add column series text check (series <> '');
+alter table books add column series_position numeric(5,1) check (series_position > 0);
+alter table books add constraint books_series_position_requires_series check (series_position is null or series is not null);
+
+create index on books (user_id, normalize_book_text(series)) where series is not null;
+
+---- create above / drop below ----
+
+alter table books drop column series_position;
+alter table books drop column series;
@@ -0,0 +1,43 @@
+-- A work is a book that may be read many times. Each books row is one reading of a work.
+create table works (
+  id bigint primary key,
+  user_id bigint not null references users on delete cascade,
+  title text not null,
+  author text not null,
+  insert_time timestamptz not null default now(),
+  update_time timestamptz not null default now()
+);
+select set_default_to_next_duid_block('works', 'id', 'work_id_seq');
+
+create unique index on works (user_id, normalize_book_text(title), normalize_book_text(author));
+
+create trigger on_work_update
+before update on works
+for each row execute procedure timestamp_update();
+
+insert into works(user_id, title, author)
+select distinct on (user_id, normalize_book_text(title), normalize_book_text(author)) user_id, title, author
+from books
+order by user_id, normalize_book_text(title), normalize_book_text(author), insert_time;
+
+alter table books add column work_id bigint references works;
+
+update books
+set work_id=works.id
+from works
+where works.user_id=books.user_id
+  and normalize_book_text(works.title)=normalize_book_text(books.title)
+  and normalize_book_text(works.author)=normalize_book_text(books.author);
+
+alter table books alter column work_id set not null;
+
+create index on books (work_id);
+
+grant select, insert, update, delete on table works to {{.app_user}};
+grant usage on sequence work_id_seq to {{.app_user}};
+
+---- create above / drop below ----
+
+alter table books drop column work_id;
+drop table works;
+drop sequence work_id_seq;
@@ -0,0 +1,43 @@
+create table book_notes (
+  id bigint primary key,
+  book_id bigint not null references books on delete cascade,
+  kind text not null check (kind in ('note', 'quote')),
+  body text not null check (body <> ''),
+  page integer check (page > 0),
+  search_vector tsvector,
+  insert_time timestamptz not null default now(),
+  update_time timestamptz not null default now()
+);
+select set_default_to_next_duid_block('book_notes', 'id', 'book_note_id_seq');
+
+create index on book_notes (book_id);
+
+create trigger on_book_note_update
+before update on book_notes
+for each row execute procedure timestamp_update();
+
+-- Notes have their own search vector so changing a note does not update its book. SearchBooks matches a book if it or
+-- any of its notes match and ranks notes with the same weight as the review.
+create function book_note_search_vector_update() returns trigger
+language plpgsql
+as $$
+  begin
+    new.search_vector = setweight(to_tsvector('english', new.body), 'D');
+    return new;
+  end;
+$$;
+
+create trigger on_book_note_search_vector_update
+before insert or update on book_notes
+for each row execute procedure book_note_search_vector_update();
+
+create index on book_notes using gin (search_vector);
+
+grant select, insert, update, delete on table book_notes to {{.app_user}};
+grant usage on sequence book_note_id_seq to {{.app_user}};
+
+---- create above / drop below ----
+
+drop table book_notes;
+drop sequence book_note_id_seq;
+drop function book_note_search_vector_update();
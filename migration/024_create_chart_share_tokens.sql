@@ -0,0 +1,11 @@
+create table chart_share_tokens (
+  user_id bigint primary key references users on delete cascade,
+  token_digest bytea not null unique,
+  insert_time timestamptz not null default now()
+);
+
+grant select, insert, update, delete on table chart_share_tokens to {{.app_user}};
+
+---- create above / drop below ----
+
+drop table chart_share_tokens;
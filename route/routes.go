@@ -2,16 +2,99 @@ package route
 
 import (
 	"fmt"
+	"net/url"
 )
 
 func UserHomePath(username string) string {
 	return fmt.Sprintf("/users/%s", username)
 }
 
+// UserHomeYearPath returns the path to the user home page with stats for year.
+func UserHomeYearPath(username string, year int) string {
+	return fmt.Sprintf("/users/%s?year=%d", username, year)
+}
+
 func BooksPath(username string) string {
 	return fmt.Sprintf("/users/%s/books", username)
 }
 
+// BookIndexParams are the query params of the book index. Empty params are omitted.
+type BookIndexParams struct {
+	Author   string
+	Format   string
+	Location string
+	From     string // finish date YYYY-MM-DD
+	To       string // finish date YYYY-MM-DD
+	Sort     string
+	After    string // cursor of the previous page
+}
+
+// BookIndexPath returns the path to the book index filtered, sorted, and paged by params.
+func BookIndexPath(username string, params BookIndexParams) string {
+	query := url.Values{}
+	for _, p := range []struct{ name, value string }{
+		{"author", params.Author},
+		{"format", params.Format},
+		{"location", params.Location},
+		{"from", params.From},
+		{"to", params.To},
+		{"sort", params.Sort},
+		{"after", params.After},
+	} {
+		if p.value != "" {
+			query.Set(p.name, p.value)
+		}
+	}
+
+	if len(query) == 0 {
+		return BooksPath(username)
+	}
+	return BooksPath(username) + "?" + query.Encode()
+}
+
+// WithSort returns params sorted by sort starting from the first page.
+func (params BookIndexParams) WithSort(sort string) BookIndexParams {
+	params.Sort = sort
+	params.After = ""
+	return params
+}
+
+// WithAfter returns params for the page after cursor after. An empty after is the first page.
+func (params BookIndexParams) WithAfter(after string) BookIndexParams {
+	params.After = after
+	return params
+}
+
+// ShelfPath returns the path to the shelf of books with status. The finished shelf is the book index.
+func ShelfPath(username string, status string) string {
+	switch status {
+	case "want_to_read":
+		return WantToReadBooksPath(username)
+	case "reading":
+		return ReadingBooksPath(username)
+	case "abandoned":
+		return AbandonedBooksPath(username)
+	default:
+		return BooksPath(username)
+	}
+}
+
+func WantToReadBooksPath(username string) string {
+	return fmt.Sprintf("/users/%s/books/want-to-read", username)
+}
+
+func ReadingBooksPath(username string) string {
+	return fmt.Sprintf("/users/%s/books/reading", username)
+}
+
+func AbandonedBooksPath(username string) string {
+	return fmt.Sprintf("/users/%s/books/abandoned", username)
+}
+
+func SearchBooksPath(username string) string {
+	return fmt.Sprintf("/users/%s/books/search", username)
+}
+
 func BookPath(username string, id int64) string {
 	return fmt.Sprintf("/users/%s/books/%d", username, id)
 }
@@ -20,6 +103,26 @@ func BookConfirmDeletePath(username string, id int64) string {
 	return fmt.Sprintf("/users/%s/books/%d/confirm_delete", username, id)
 }
 
+func FinishBookPath(username string, id int64) string {
+	return fmt.Sprintf("/users/%s/books/%d/finish", username, id)
+}
+
+func RereadBookPath(username string, id int64) string {
+	return fmt.Sprintf("/users/%s/books/new?reread=%d", username, id)
+}
+
+func BookNotesPath(username string, bookID int64) string {
+	return fmt.Sprintf("/users/%s/books/%d/notes", username, bookID)
+}
+
+func BookNotePath(username string, bookID int64, noteID int64) string {
+	return fmt.Sprintf("/users/%s/books/%d/notes/%d", username, bookID, noteID)
+}
+
+func EditBookNotePath(username string, bookID int64, noteID int64) string {
+	return fmt.Sprintf("/users/%s/books/%d/notes/%d/edit", username, bookID, noteID)
+}
+
 func EditBookPath(username string, id int64) string {
 	return fmt.Sprintf("/users/%s/books/%d/edit", username, id)
 }
@@ -36,10 +139,98 @@ func ImportBookCSVPath(username string) string {
 	return fmt.Sprintf("/users/%s/books/import_csv", username)
 }
 
+func PreviewImportBookCSVPath(username string) string {
+	return fmt.Sprintf("/users/%s/books/import_csv/preview", username)
+}
+
 func ExportBookCSVPath(username string) string {
 	return fmt.Sprintf("/users/%s/books.csv", username)
 }
 
+func ExportBookJSONPath(username string) string {
+	return fmt.Sprintf("/users/%s/books.json", username)
+}
+
+func ImportBookJSONPath(username string) string {
+	return fmt.Sprintf("/users/%s/books/import_json", username)
+}
+
+func YearReviewPath(username string, year int) string {
+	return fmt.Sprintf("/users/%s/years/%d", username, year)
+}
+
+func StatsPath(username string) string {
+	return fmt.Sprintf("/users/%s/stats", username)
+}
+
+func BooksPerYearChartPath(username string) string {
+	return fmt.Sprintf("/users/%s/charts/books_per_year.svg", username)
+}
+
+func BooksPerMonthChartPath(username string) string {
+	return fmt.Sprintf("/users/%s/charts/books_per_month.svg", username)
+}
+
+func BooksPerFormatChartPath(username string) string {
+	return fmt.Sprintf("/users/%s/charts/books_per_format.svg", username)
+}
+
+func ChartShareTokenPath(username string) string {
+	return fmt.Sprintf("/users/%s/chart_share_token", username)
+}
+
+func SharedBooksPerYearChartPath(token string) string {
+	return fmt.Sprintf("/shared_charts/%s/books_per_year.svg", token)
+}
+
+func SharedBooksPerMonthChartPath(token string) string {
+	return fmt.Sprintf("/shared_charts/%s/books_per_month.svg", token)
+}
+
+func SharedBooksPerFormatChartPath(token string) string {
+	return fmt.Sprintf("/shared_charts/%s/books_per_format.svg", token)
+}
+
+func ReadingGoalPath(username string) string {
+	return fmt.Sprintf("/users/%s/reading_goal", username)
+}
+
+func EditReadingGoalPath(username string, year int) string {
+	return fmt.Sprintf("/users/%s/reading_goal/edit?year=%d", username, year)
+}
+
+func AuthorsPath(username string) string {
+	return fmt.Sprintf("/users/%s/authors", username)
+}
+
+func AuthorPath(username string, id int64) string {
+	return fmt.Sprintf("/users/%s/authors/%d", username, id)
+}
+
+func EditAuthorPath(username string, id int64) string {
+	return fmt.Sprintf("/users/%s/authors/%d/edit", username, id)
+}
+
+func SeriesIndexPath(username string) string {
+	return fmt.Sprintf("/users/%s/series", username)
+}
+
+func SeriesPath(username string, name string) string {
+	return fmt.Sprintf("/users/%s/series/%s", username, url.PathEscape(name))
+}
+
+func TagPath(username string, tag string) string {
+	return fmt.Sprintf("/users/%s/tags/%s", username, url.PathEscape(tag))
+}
+
+func APITokensPath(username string) string {
+	return fmt.Sprintf("/users/%s/api_tokens", username)
+}
+
+func APITokenPath(username string, id int64) string {
+	return fmt.Sprintf("/users/%s/api_tokens/%d", username, id)
+}
+
 func NewUserRegistrationPath() string {
 	return "/user_registration/new"
 }
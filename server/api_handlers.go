@@ -0,0 +1,320 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/validate"
+	errors "golang.org/x/xerrors"
+)
+
+// apiBook is the JSON representation of a data.Book. Dates are formatted as YYYY-MM-DD.
+type apiBook struct {
+	ID             int64     `json:"id"`
+	Title          string    `json:"title"`
+	Author         string    `json:"author"`
+	Status         string    `json:"status"`
+	StartDate      string    `json:"startDate"`
+	FinishDate     string    `json:"finishDate"`
+	Format         string    `json:"format"`
+	Location       string    `json:"location"`
+	Rating         float64   `json:"rating"`
+	Review         string    `json:"review"`
+	ISBN           string    `json:"isbn"`
+	PageCount      int32     `json:"pageCount"`
+	AudioMinutes   int32     `json:"audioMinutes"`
+	Series         string    `json:"series"`
+	SeriesPosition float64   `json:"seriesPosition"`
+	Tags           []string  `json:"tags"`
+	InsertTime     time.Time `json:"insertTime"`
+	UpdateTime     time.Time `json:"updateTime"`
+}
+
+func newAPIBook(book *data.Book) *apiBook {
+	ab := &apiBook{
+		ID:             book.ID,
+		Title:          book.Title,
+		Author:         book.Author,
+		Status:         book.Status,
+		Format:         book.Format,
+		Location:       book.Location,
+		Rating:         book.Rating,
+		Review:         book.Review,
+		ISBN:           book.ISBN,
+		PageCount:      book.PageCount,
+		AudioMinutes:   book.AudioMinutes,
+		Series:         book.Series,
+		SeriesPosition: book.SeriesPosition,
+		Tags:           book.Tags,
+		InsertTime:     book.InsertTime,
+		UpdateTime:     book.UpdateTime,
+	}
+	if !book.StartDate.IsZero() {
+		ab.StartDate = book.StartDate.Format("2006-01-02")
+	}
+	if !book.FinishDate.IsZero() {
+		ab.FinishDate = book.FinishDate.Format("2006-01-02")
+	}
+	if ab.Tags == nil {
+		ab.Tags = []string{}
+	}
+
+	return ab
+}
+
+// book converts ab to a data.Book. ID, InsertTime, and UpdateTime are ignored.
+func (ab *apiBook) book() (data.Book, validate.Errors) {
+	book := data.Book{
+		Title:          ab.Title,
+		Author:         ab.Author,
+		Status:         ab.Status,
+		Format:         ab.Format,
+		Location:       ab.Location,
+		Rating:         ab.Rating,
+		Review:         ab.Review,
+		ISBN:           ab.ISBN,
+		PageCount:      ab.PageCount,
+		AudioMinutes:   ab.AudioMinutes,
+		Series:         ab.Series,
+		SeriesPosition: ab.SeriesPosition,
+		Tags:           ab.Tags,
+	}
+	v := validate.New()
+
+	var err error
+	if ab.StartDate != "" {
+		book.StartDate, err = time.Parse("2006-01-02", ab.StartDate)
+		if err != nil {
+			v.Add("startDate", errors.New("is not a YYYY-MM-DD date"))
+		}
+	}
+
+	if ab.FinishDate != "" {
+		book.FinishDate, err = time.Parse("2006-01-02", ab.FinishDate)
+		if err != nil {
+			v.Add("finishDate", errors.New("is not a YYYY-MM-DD date"))
+		}
+	}
+
+	if v.Err() != nil {
+		return book, v.Err().(validate.Errors)
+	}
+
+	return book, nil
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf)
+}
+
+func writeJSONError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSON(w, r, status, map[string]string{"error": message})
+}
+
+func writeJSONValidationErrors(w http.ResponseWriter, r *http.Request, verr validate.Errors) {
+	writeJSON(w, r, http.StatusUnprocessableEntity, map[string]interface{}{"errors": verr})
+}
+
+// apiTokenHandler authenticates the request with the bearer token in the Authorization header.
+func apiTokenHandler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			db := ctx.Value(RequestDBKey).(dbconn)
+
+			authorization := r.Header.Get("Authorization")
+			if !strings.HasPrefix(authorization, "Bearer ") {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeJSONError(w, r, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+
+			user, err := data.GetUserMinByAPIToken(ctx, db, strings.TrimPrefix(authorization, "Bearer "))
+			if err != nil {
+				var nfErr *data.NotFoundError
+				if errors.As(err, &nfErr) {
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					writeJSONError(w, r, http.StatusUnauthorized, "invalid token")
+				} else {
+					InternalServerErrorHandler(w, r, err)
+				}
+				return
+			}
+
+			ctx = context.WithValue(ctx, RequestAPIUserKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// getAPIUserBook returns the book specified by the id URL param. It writes a not found response and returns nil if the
+// book does not exist or does not belong to the API user.
+func getAPIUserBook(w http.ResponseWriter, r *http.Request) *data.Book {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	apiUser := ctx.Value(RequestAPIUserKey).(*data.UserMin)
+	bookID := int64URLParam(r, "id")
+
+	book, err := data.GetBook(ctx, db, bookID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			writeJSONError(w, r, http.StatusNotFound, "not found")
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return nil
+	}
+
+	if book.UserID != apiUser.ID {
+		writeJSONError(w, r, http.StatusNotFound, "not found")
+		return nil
+	}
+
+	return book
+}
+
+func APIBookIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	apiUser := ctx.Value(RequestAPIUserKey).(*data.UserMin)
+
+	books, err := data.GetAllBooks(ctx, db, apiUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	apiBooks := make([]*apiBook, 0, len(books))
+	for _, book := range books {
+		apiBooks = append(apiBooks, newAPIBook(book))
+	}
+
+	writeJSON(w, r, http.StatusOK, apiBooks)
+}
+
+func APIBookShow(w http.ResponseWriter, r *http.Request) {
+	book := getAPIUserBook(w, r)
+	if book == nil {
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, newAPIBook(book))
+}
+
+func APIBookCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	apiUser := ctx.Value(RequestAPIUserKey).(*data.UserMin)
+
+	ab := &apiBook{Status: data.BookStatusFinished}
+	err := json.NewDecoder(r.Body).Decode(ab)
+	if err != nil {
+		writeJSONError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attrs, verr := ab.book()
+	if verr != nil {
+		writeJSONValidationErrors(w, r, verr)
+		return
+	}
+	attrs.UserID = apiUser.ID
+
+	book, err := data.CreateBook(ctx, db, attrs)
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			writeJSONValidationErrors(w, r, verr)
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	book, err = data.GetBook(ctx, db, book.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, newAPIBook(book))
+}
+
+// APIBookUpdate updates the fields present in the request body. Fields that are not present are unchanged.
+func APIBookUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+
+	book := getAPIUserBook(w, r)
+	if book == nil {
+		return
+	}
+
+	ab := newAPIBook(book)
+	err := json.NewDecoder(r.Body).Decode(ab)
+	if err != nil {
+		writeJSONError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attrs, verr := ab.book()
+	if verr != nil {
+		writeJSONValidationErrors(w, r, verr)
+		return
+	}
+	attrs.ID = book.ID
+
+	err = data.UpdateBook(ctx, db, attrs)
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			writeJSONValidationErrors(w, r, verr)
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	book, err = data.GetBook(ctx, db, book.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, newAPIBook(book))
+}
+
+func APIBookDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+
+	book := getAPIUserBook(w, r)
+	if book == nil {
+		return
+	}
+
+	err := data.DeleteBook(ctx, db, book.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIBookRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	book := &data.Book{
+		ID:         42,
+		Title:      "Paradise Lost",
+		Author:     "John Milton",
+		Status:     data.BookStatusFinished,
+		StartDate:  time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC),
+		FinishDate: time.Date(2019, 2, 3, 0, 0, 0, 0, time.UTC),
+		Format:     "text",
+		Rating:     4.5,
+		Tags:       []string{"poetry"},
+	}
+
+	ab := newAPIBook(book)
+	require.Equal(t, "2019-01-02", ab.StartDate)
+	require.Equal(t, "2019-02-03", ab.FinishDate)
+
+	parsed, verr := ab.book()
+	require.Nil(t, verr)
+	require.Equal(t, book.Title, parsed.Title)
+	require.True(t, book.StartDate.Equal(parsed.StartDate))
+	require.True(t, book.FinishDate.Equal(parsed.FinishDate))
+	require.Equal(t, book.Rating, parsed.Rating)
+	require.Equal(t, book.Tags, parsed.Tags)
+
+	ab.FinishDate = "2/3/2019"
+	_, verr = ab.book()
+	require.NotEmpty(t, verr.Get("finishDate"))
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+func APITokenIndex(w http.ResponseWriter, r *http.Request) {
+	renderAPITokenIndex(w, r, "", "", nil)
+}
+
+func renderAPITokenIndex(w http.ResponseWriter, r *http.Request, name string, newToken string, verr validate.Errors) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	apiTokens, err := data.GetAPITokens(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = view.APITokenIndex(w, baseViewArgsFromRequest(r), apiTokens, name, newToken, verr)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// APITokenCreate creates an API token and renders it. This is the only time the token is available.
+func APITokenCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	name := r.FormValue("name")
+	token, _, err := data.CreateAPIToken(ctx, db, pathUser.ID, name)
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			renderAPITokenIndex(w, r, name, "", verr)
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	renderAPITokenIndex(w, r, "", token, nil)
+}
+
+func APITokenDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+	apiTokenID := int64URLParam(r, "id")
+
+	err := data.DeleteAPIToken(ctx, db, pathUser.ID, apiTokenID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+
+	http.Redirect(w, r, route.APITokensPath(pathUser.Username), http.StatusSeeOther)
+}
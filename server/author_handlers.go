@@ -0,0 +1,128 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+func AuthorIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	authors, err := data.GetAuthors(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = view.AuthorIndex(w, baseViewArgsFromRequest(r), authors)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// getPathUserAuthor returns the author specified by the id URL param. It writes a not found response and returns nil
+// if the author does not exist or does not belong to the path user.
+func getPathUserAuthor(w http.ResponseWriter, r *http.Request) *data.Author {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+	authorID := int64URLParam(r, "id")
+
+	author, err := data.GetAuthor(ctx, db, authorID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return nil
+	}
+
+	if author.UserID != pathUser.ID {
+		NotFoundHandler(w, r)
+		return nil
+	}
+
+	return author
+}
+
+func AuthorShow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+
+	author := getPathUserAuthor(w, r)
+	if author == nil {
+		return
+	}
+
+	books, err := data.GetBooksByAuthor(ctx, db, author.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = view.AuthorShow(w, baseViewArgsFromRequest(r), author, books)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+func AuthorEdit(w http.ResponseWriter, r *http.Request) {
+	author := getPathUserAuthor(w, r)
+	if author == nil {
+		return
+	}
+
+	err := view.AuthorEdit(w, baseViewArgsFromRequest(r), author.ID, view.NewAuthorEditForm(author), nil)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// AuthorUpdate renames an author and sets its aliases. Other authors matching the new name or aliases are merged into
+// the author.
+func AuthorUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	author := getPathUserAuthor(w, r)
+	if author == nil {
+		return
+	}
+
+	form := view.AuthorEditForm{
+		Name:    r.FormValue("name"),
+		Aliases: r.FormValue("aliases"),
+	}
+	attrs := form.Parse()
+	attrs.ID = author.ID
+
+	err := data.UpdateAuthor(ctx, db, attrs)
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			err := view.AuthorEdit(w, baseViewArgsFromRequest(r), author.ID, form, verr)
+			if err != nil {
+				InternalServerErrorHandler(w, r, err)
+			}
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, route.AuthorPath(pathUser.Username, author.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+// bookIndexParamsFromQuery returns the book index params in query and the filter they select. A from or to param that
+// is not a YYYY-MM-DD date is dropped and does not restrict the filter.
+func bookIndexParamsFromQuery(query url.Values) (route.BookIndexParams, data.BookFilter) {
+	params := route.BookIndexParams{
+		Author:   strings.TrimSpace(query.Get("author")),
+		Format:   query.Get("format"),
+		Location: strings.TrimSpace(query.Get("location")),
+		Sort:     query.Get("sort"),
+		After:    query.Get("after"),
+	}
+	if params.Sort == "" {
+		params.Sort = data.BookSortFinishDate
+	}
+
+	switch params.Format {
+	case "text", "audio", "video":
+	default:
+		params.Format = ""
+	}
+
+	filter := data.BookFilter{
+		Author:   params.Author,
+		Format:   params.Format,
+		Location: params.Location,
+	}
+
+	if from, err := time.Parse("2006-01-02", query.Get("from")); err == nil {
+		params.From = query.Get("from")
+		filter.FinishedFrom = from
+	}
+	if to, err := time.Parse("2006-01-02", query.Get("to")); err == nil {
+		params.To = query.Get("to")
+		filter.FinishedTo = to
+	}
+
+	return params, filter
+}
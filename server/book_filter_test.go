@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookIndexParamsFromQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query  string
+		params route.BookIndexParams
+		filter data.BookFilter
+	}{
+		{
+			query:  "",
+			params: route.BookIndexParams{Sort: data.BookSortFinishDate},
+		},
+		{
+			query: "author=+Frank+Herbert+&format=audio&location=Home&from=2018-01-01&to=2018-12-31&sort=title&after=abc",
+			params: route.BookIndexParams{
+				Author:   "Frank Herbert",
+				Format:   "audio",
+				Location: "Home",
+				From:     "2018-01-01",
+				To:       "2018-12-31",
+				Sort:     data.BookSortTitle,
+				After:    "abc",
+			},
+			filter: data.BookFilter{
+				Author:       "Frank Herbert",
+				Format:       "audio",
+				Location:     "Home",
+				FinishedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+				FinishedTo:   time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			query:  "format=scroll&from=yesterday&to=2018-13-01",
+			params: route.BookIndexParams{Sort: data.BookSortFinishDate},
+		},
+	}
+
+	for i, tt := range tests {
+		query, err := url.ParseQuery(tt.query)
+		require.NoError(t, err)
+
+		params, filter := bookIndexParamsFromQuery(query)
+		require.Equalf(t, tt.params, params, "%d", i)
+		require.Equalf(t, tt.filter, filter, "%d", i)
+	}
+}
@@ -1,56 +1,140 @@
 package server
 
 import (
-	"bytes"
 	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/route"
 	"github.com/jackc/booklog/validate"
 	"github.com/jackc/booklog/view"
-	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/hlog"
 	errors "golang.org/x/xerrors"
 )
 
+// bookIndexPageSize is the number of books on each page of the book index.
+const bookIndexPageSize = 50
+
 func BookIndex(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
 
-	books, err := data.GetAllBooks(ctx, db, pathUser.ID)
+	params, filter := bookIndexParamsFromQuery(r.URL.Query())
+
+	page, err := data.GetBookPage(ctx, db, data.BookPageQuery{
+		UserID: pathUser.ID,
+		Status: data.BookStatusFinished,
+		Filter: filter,
+		Sort:   params.Sort,
+		After:  params.After,
+		Limit:  bookIndexPageSize,
+	})
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+
+	err = view.BookIndex(w, baseViewArgsFromRequest(r), params, page)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
 	}
+}
 
-	yearBooksLists := make([]*view.YearBookList, 0)
-	var ybl *view.YearBookList
+func WantToReadBookIndex(w http.ResponseWriter, r *http.Request) {
+	renderBookShelf(w, r, data.BookStatusWantToRead)
+}
 
-	for _, book := range books {
-		year := book.FinishDate.Year()
-		if ybl == nil || year != ybl.Year {
-			ybl = &view.YearBookList{Year: year}
-			yearBooksLists = append(yearBooksLists, ybl)
-		}
+func ReadingBookIndex(w http.ResponseWriter, r *http.Request) {
+	renderBookShelf(w, r, data.BookStatusReading)
+}
 
-		ybl.Books = append(ybl.Books, book)
+func AbandonedBookIndex(w http.ResponseWriter, r *http.Request) {
+	renderBookShelf(w, r, data.BookStatusAbandoned)
+}
+
+func renderBookShelf(w http.ResponseWriter, r *http.Request, status string) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	books, err := data.GetBooksByStatus(ctx, db, pathUser.ID, status)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
 	}
 
-	err = view.BookIndex(w, baseViewArgsFromRequest(r), yearBooksLists)
+	err = view.BookShelf(w, baseViewArgsFromRequest(r), status, books)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
 	}
 }
 
+func BookSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	var books []*data.Book
+	if query != "" {
+		var err error
+		books, err = data.SearchBooks(ctx, db, pathUser.ID, query)
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+			return
+		}
+	}
+
+	err := view.BookSearch(w, baseViewArgsFromRequest(r), query, books)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// BookNew shows the new book form. The reread query param is the ID of a book to read again. The form is filled in from
+// that book so saving it adds another reading of the same work.
 func BookNew(w http.ResponseWriter, r *http.Request) {
-	var form view.BookEditForm
-	err := view.BookNew(w, baseViewArgsFromRequest(r), form, nil)
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	form := view.BookEditForm{Status: data.BookStatusFinished}
+
+	if rereadID, err := strconv.ParseInt(r.URL.Query().Get("reread"), 10, 64); err == nil {
+		book, err := data.GetBook(ctx, db, rereadID)
+		if err != nil {
+			var nfErr *data.NotFoundError
+			if errors.As(err, &nfErr) {
+				NotFoundHandler(w, r)
+			} else {
+				InternalServerErrorHandler(w, r, err)
+			}
+			return
+		}
+		if book.UserID != pathUser.ID {
+			NotFoundHandler(w, r)
+			return
+		}
+
+		form = view.NewRereadBookEditForm(book)
+	}
+
+	err := view.BookNew(w, baseViewArgsFromRequest(r), form, nil, nil)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
@@ -62,16 +146,10 @@ func BookCreate(w http.ResponseWriter, r *http.Request) {
 	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
 
-	form := view.BookEditForm{
-		Title:      r.FormValue("title"),
-		Author:     r.FormValue("author"),
-		FinishDate: r.FormValue("finishDate"),
-		Format:     r.FormValue("format"),
-		Location:   r.FormValue("location"),
-	}
+	form := bookEditFormFromRequest(r)
 	attrs, verr := form.Parse()
 	if verr != nil {
-		err := view.BookNew(w, baseViewArgsFromRequest(r), form, verr)
+		err := view.BookNew(w, baseViewArgsFromRequest(r), form, verr, nil)
 		if err != nil {
 			InternalServerErrorHandler(w, r, err)
 		}
@@ -79,11 +157,28 @@ func BookCreate(w http.ResponseWriter, r *http.Request) {
 	}
 	attrs.UserID = pathUser.ID
 
+	// Warn about a likely duplicate once. Submitting the form again saves the book anyway.
+	if r.FormValue("allowDuplicate") == "" {
+		duplicate, err := data.FindDuplicateBook(ctx, db, attrs)
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+			return
+		}
+
+		if duplicate != nil {
+			err := view.BookNew(w, baseViewArgsFromRequest(r), form, nil, duplicate)
+			if err != nil {
+				InternalServerErrorHandler(w, r, err)
+			}
+			return
+		}
+	}
+
 	book, err := data.CreateBook(ctx, db, attrs)
 	if err != nil {
 		var verr validate.Errors
 		if errors.As(err, &verr) {
-			err := view.BookNew(w, baseViewArgsFromRequest(r), form, verr)
+			err := view.BookNew(w, baseViewArgsFromRequest(r), form, verr, nil)
 			if err != nil {
 				InternalServerErrorHandler(w, r, err)
 			}
@@ -97,23 +192,33 @@ func BookCreate(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, route.BookPath(pathUser.Username, book.ID), http.StatusSeeOther)
 }
 
-func BookConfirmDelete(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	db := ctx.Value(RequestDBKey).(dbconn)
-	bookID := int64URLParam(r, "id")
+func bookEditFormFromRequest(r *http.Request) view.BookEditForm {
+	return view.BookEditForm{
+		Title:          r.FormValue("title"),
+		Author:         r.FormValue("author"),
+		Status:         r.FormValue("status"),
+		StartDate:      r.FormValue("startDate"),
+		FinishDate:     r.FormValue("finishDate"),
+		Format:         r.FormValue("format"),
+		Location:       r.FormValue("location"),
+		Rating:         r.FormValue("rating"),
+		Review:         r.FormValue("review"),
+		ISBN:           r.FormValue("isbn"),
+		PageCount:      r.FormValue("pageCount"),
+		AudioMinutes:   r.FormValue("audioMinutes"),
+		Series:         r.FormValue("series"),
+		SeriesPosition: r.FormValue("seriesPosition"),
+		Tags:           r.FormValue("tags"),
+	}
+}
 
-	book, err := data.GetBook(ctx, db, bookID)
-	if err != nil {
-		var nfErr data.NotFoundError
-		if errors.As(err, nfErr) {
-			NotFoundHandler(w, r)
-		} else {
-			InternalServerErrorHandler(w, r, err)
-		}
+func BookConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	book := getPathUserBook(w, r)
+	if book == nil {
 		return
 	}
 
-	err = view.BookConfirmDelete(w, baseViewArgsFromRequest(r), book)
+	err := view.BookConfirmDelete(w, baseViewArgsFromRequest(r), book)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
@@ -124,12 +229,16 @@ func BookDelete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
-	bookID := int64URLParam(r, "id")
 
-	err := data.DeleteBook(ctx, db, bookID)
+	book := getPathUserBook(w, r)
+	if book == nil {
+		return
+	}
+
+	err := data.DeleteBook(ctx, db, book.ID)
 	if err != nil {
-		var nfErr data.NotFoundError
-		if errors.As(err, nfErr) {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
 			NotFoundHandler(w, r)
 		} else {
 			InternalServerErrorHandler(w, r, err)
@@ -141,22 +250,39 @@ func BookDelete(w http.ResponseWriter, r *http.Request) {
 }
 
 func BookShow(w http.ResponseWriter, r *http.Request) {
+	book := getPathUserBook(w, r)
+	if book == nil {
+		return
+	}
+
+	renderBookShow(w, r, book, view.BookNoteForm{Kind: data.BookNoteKindNote}, nil)
+}
+
+// renderBookShow renders book with its readings and notes. noteForm and verr are the new note form and its errors. It
+// writes a not found response if book does not belong to the path user.
+func renderBookShow(w http.ResponseWriter, r *http.Request, book *data.Book, noteForm view.BookNoteForm, verr validate.Errors) {
 	ctx := r.Context()
 	db := ctx.Value(RequestDBKey).(dbconn)
-	bookID := int64URLParam(r, "id")
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
 
-	book, err := data.GetBook(ctx, db, bookID)
+	if book.UserID != pathUser.ID {
+		NotFoundHandler(w, r)
+		return
+	}
+
+	readings, err := data.GetReadings(ctx, db, book.WorkID)
 	if err != nil {
-		var nfErr data.NotFoundError
-		if errors.As(err, nfErr) {
-			NotFoundHandler(w, r)
-		} else {
-			InternalServerErrorHandler(w, r, err)
-		}
+		InternalServerErrorHandler(w, r, err)
 		return
 	}
 
-	err = view.BookShow(w, baseViewArgsFromRequest(r), book)
+	notes, err := data.GetBookNotes(ctx, db, book.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = view.BookShow(w, baseViewArgsFromRequest(r), book, readings, notes, noteForm, verr)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
@@ -164,26 +290,13 @@ func BookShow(w http.ResponseWriter, r *http.Request) {
 }
 
 func BookEdit(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	db := ctx.Value(RequestDBKey).(dbconn)
-	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
-	bookID := int64URLParam(r, "id")
-
-	var form view.BookEditForm
-	var FinishDate time.Time
-	err := db.QueryRow(ctx, "select title, author, finish_date, format, coalesce(location, '') from books where id=$1 and user_id=$2", bookID, pathUser.ID).
-		Scan(&form.Title, &form.Author, &FinishDate, &form.Format, &form.Location)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			NotFoundHandler(w, r)
-		} else {
-			InternalServerErrorHandler(w, r, err)
-		}
+	book := getPathUserBook(w, r)
+	if book == nil {
 		return
 	}
-	form.FinishDate = FinishDate.Format("2006-01-02")
+	form := view.NewBookEditForm(book)
 
-	err = view.BookEdit(w, baseViewArgsFromRequest(r), bookID, form, nil)
+	err := view.BookEdit(w, baseViewArgsFromRequest(r), book.ID, form, nil)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
@@ -194,15 +307,14 @@ func BookUpdate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
-	bookID := int64URLParam(r, "id")
 
-	form := view.BookEditForm{
-		Title:      r.FormValue("title"),
-		Author:     r.FormValue("author"),
-		FinishDate: r.FormValue("finishDate"),
-		Format:     r.FormValue("format"),
-		Location:   r.FormValue("location"),
+	book := getPathUserBook(w, r)
+	if book == nil {
+		return
 	}
+	bookID := book.ID
+
+	form := bookEditFormFromRequest(r)
 	attrs, verr := form.Parse()
 	if verr != nil {
 		err := view.BookEdit(w, baseViewArgsFromRequest(r), bookID, form, verr)
@@ -224,8 +336,28 @@ func BookUpdate(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		var nfErr data.NotFoundError
-		if errors.As(err, nfErr) {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+
+	http.Redirect(w, r, route.BookPath(pathUser.Username, bookID), http.StatusSeeOther)
+}
+
+func BookFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+	bookID := int64URLParam(r, "id")
+
+	err := data.FinishBook(ctx, db, pathUser.ID, bookID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
 			NotFoundHandler(w, r)
 		} else {
 			InternalServerErrorHandler(w, r, err)
@@ -237,18 +369,18 @@ func BookUpdate(w http.ResponseWriter, r *http.Request) {
 }
 
 func BookImportCSVForm(w http.ResponseWriter, r *http.Request) {
-	err := view.BookImportCSVForm(w, baseViewArgsFromRequest(r), nil)
+	err := view.BookImportCSVForm(w, baseViewArgsFromRequest(r), nil, nil)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
 	}
 }
 
-// TODO - do transactions right
-
-func BookImportCSV(w http.ResponseWriter, r *http.Request) {
+// BookImportCSVPreview shows how an uploaded CSV will be imported. The CSV is carried in the preview form so
+// confirming the import does not require uploading it again.
+func BookImportCSVPreview(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	conn := ctx.Value(RequestDBKey).(dbconn)
+	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
 
 	r.ParseMultipartForm(10 << 20)
@@ -260,101 +392,211 @@ func BookImportCSV(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	err = importBooksFromCSV(ctx, conn, pathUser.ID, file)
+	csvText := &strings.Builder{}
+	_, err = io.Copy(csvText, file)
 	if err != nil {
-		err := view.BookImportCSVForm(w, baseViewArgsFromRequest(r), err)
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	preview, err := previewBooksFromCSV(csvText.String())
+	if err != nil {
+		err := view.BookImportCSVForm(w, baseViewArgsFromRequest(r), err, nil)
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+
+	for i := range preview.Rows {
+		row := &preview.Rows[i]
+		if row.Errors != nil {
+			continue
+		}
+
+		attrs, _ := row.Form.Parse()
+		attrs.UserID = pathUser.ID
+		duplicate, err := data.FindDuplicateBook(ctx, db, attrs)
 		if err != nil {
 			InternalServerErrorHandler(w, r, err)
 			return
 		}
+		row.Duplicate = duplicate != nil
+	}
+
+	err = view.BookImportCSVPreview(w, baseViewArgsFromRequest(r), preview)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
 		return
 	}
+}
 
-	http.Redirect(w, r, route.BooksPath(pathUser.Username), http.StatusSeeOther)
+// TODO - do transactions right
+
+func BookImportCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	conn := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	r.ParseMultipartForm(10 << 20)
+	duplicateAction := duplicateActionFromForm(r.FormValue("duplicateAction"))
+
+	if r.FormValue("source") == "goodreads" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+			return
+		}
+		defer file.Close()
+
+		result, err := importBooksFromGoodreadsCSV(ctx, conn, pathUser.ID, file, duplicateAction)
+		err = view.BookImportCSVForm(w, baseViewArgsFromRequest(r), err, result)
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+
+	result, err := importBooksFromCSV(ctx, conn, pathUser.ID, strings.NewReader(r.FormValue("csv")), duplicateAction)
+	err = view.BookImportCSVForm(w, baseViewArgsFromRequest(r), err, result)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+	}
 }
 
-func importBooksFromCSV(ctx context.Context, db dbconn, ownerID int64, r io.Reader) error {
+// importBooksFromCSV imports books from a CSV with a header row. Columns are matched to book fields by header name.
+// Rows that duplicate an existing book are handled according to duplicateAction.
+func importBooksFromCSV(ctx context.Context, db dbconn, ownerID int64, r io.Reader, duplicateAction string) (*view.BookImportResult, error) {
 	records, err := csv.NewReader(r).ReadAll()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(records) < 2 {
-		return errors.New("CSV must have at least 2 rows")
+		return nil, errors.New("CSV must have at least 2 rows")
 	}
 
-	if len(records[0]) < 5 {
-		return errors.New("CSV must have at least 5 columns")
+	mapping, err := newCSVColumnMapping(records[0])
+	if err != nil {
+		return nil, err
 	}
 
 	tx, err := db.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
+	result := &view.BookImportResult{}
 	for i, record := range records[1:] {
-		form := view.BookEditForm{
-			Title:      record[0],
-			Author:     record[1],
-			FinishDate: record[2],
-			Format:     record[3],
-			Location:   record[4],
-		}
-		if form.Format == "" {
-			form.Format = "text"
-		}
-
+		form := mapping.form(record)
 		attrs, verr := form.Parse()
 		if verr != nil {
-			return errors.Errorf("row %d: %w", i+2, verr)
+			return nil, errors.Errorf("row %d: %w", i+2, verr)
 		}
 		attrs.UserID = ownerID
 
-		_, err := data.CreateBook(ctx, tx, attrs)
+		action, _, err := importBook(ctx, tx, attrs, duplicateAction, func(duplicate *data.Book) (data.Book, error) {
+			attrs, verr := mapping.updateForm(duplicate, record).Parse()
+			if verr != nil {
+				return data.Book{}, verr
+			}
+			return attrs, nil
+		})
 		if err != nil {
-			return errors.Errorf("row %d: %w", i+2, err)
+			return nil, errors.Errorf("row %d: %w", i+2, err)
+		}
+		recordImportedBook(result, fmt.Sprintf("row %d (%s)", i+2, attrs.Title), action)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// csvImportPreviewRowCount is the number of rows parsed for the import preview.
+const csvImportPreviewRowCount = 10
+
+// previewBooksFromCSV parses csvText the same way importBooksFromCSV does without saving anything.
+func previewBooksFromCSV(csvText string) (*view.CSVImportPreview, error) {
+	records, err := csv.NewReader(strings.NewReader(csvText)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("CSV must have at least 2 rows")
+	}
+
+	header := records[0]
+	mapping, err := newCSVColumnMapping(header)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &view.CSVImportPreview{
+		CSV:              csvText,
+		ColumnMatches:    mapping.columnMatches(header),
+		UnmatchedHeaders: mapping.unmatchedHeaders(header),
+		RowCount:         len(records) - 1,
+	}
+
+	for i, record := range records[1:] {
+		if i == csvImportPreviewRowCount {
+			break
+		}
+
+		row := view.BookImportPreviewRow{RowNum: i + 2, Form: mapping.form(record)}
+		book, verr := row.Form.Parse()
+		if verr == nil {
+			book.Normalize()
+			verr = book.Validate()
 		}
+		row.Errors = verr
+		preview.Rows = append(preview.Rows, row)
 	}
 
-	return tx.Commit(ctx)
+	return preview, nil
 }
 
+// BookExportCSV streams all books as CSV with the columns in csvExportHeader.
 func BookExportCSV(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
 
-	buf := &bytes.Buffer{}
-	csvWriter := csv.NewWriter(buf)
-	csvWriter.Write([]string{"title", "author", "finish_date", "format"})
-
-	rows, _ := db.Query(ctx, `select title, author, finish_date, format
-from books
-where user_id=$1
-order by finish_date desc`, pathUser.ID)
-	for rows.Next() {
-		var title, author, format string
-		var finishDate time.Time
-		rows.Scan(&title, &author, &finishDate, &format)
-		csvWriter.Write([]string{title, author, finishDate.Format("2006-01-02"), format})
-	}
-	if rows.Err() != nil {
-		InternalServerErrorHandler(w, r, rows.Err())
-		return
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=booklog-%s.csv", pathUser.Username))
+
+	// csv.Writer buffers its output so an error before the first few KB are written still results in an error
+	// response. After that the response is already committed so the error is only logged and the download is
+	// truncated.
+	sw := &startedResponseWriter{ResponseWriter: w}
+	handleErr := func(err error) {
+		if sw.started {
+			hlog.FromRequest(r).Error().Err(err).Msg("CSV export failed after response started")
+			return
+		}
+		InternalServerErrorHandler(w, r, err)
 	}
 
-	csvWriter.Flush()
-	if csvWriter.Error() != nil {
-		InternalServerErrorHandler(w, r, csvWriter.Error())
+	csvWriter := csv.NewWriter(sw)
+	csvWriter.Write(csvExportHeader)
+
+	err := data.EachBook(ctx, db, pathUser.ID, func(book *data.Book) error {
+		return csvWriter.Write(bookCSVRecord(book))
+	})
+	if err != nil {
+		handleErr(err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/csv")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=booklog-%s.csv", pathUser.Username))
-	_, err := buf.WriteTo(w)
-	if err != nil {
-		InternalServerErrorHandler(w, r, err)
+	csvWriter.Flush()
+	if csvWriter.Error() != nil {
+		handleErr(csvWriter.Error())
 		return
 	}
 }
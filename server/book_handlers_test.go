@@ -40,8 +40,9 @@ func TestImportBooksFromCSV(t *testing.T) {
 	The Dilbert Future ,Scott Adams ,7/10/2005,text,
 	Napoleon The Man Behind the Myth,Adam Zamoyski,6/17/2019,audio,`
 
-	err = importBooksFromCSV(ctx, tx, userID, strings.NewReader(in))
+	result, err := importBooksFromCSV(ctx, tx, userID, strings.NewReader(in), duplicateSkip)
 	require.NoError(t, err)
+	require.Equal(t, 3, result.ImportedCount)
 
 	var bookCount int64
 	err = tx.QueryRow(ctx, "select count(*) from books where user_id=$1", userID).Scan(&bookCount)
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+// getPathUserBook returns the book specified by the id URL param. It writes a not found response and returns nil if
+// the book does not exist or does not belong to the path user.
+func getPathUserBook(w http.ResponseWriter, r *http.Request) *data.Book {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+	bookID := int64URLParam(r, "id")
+
+	book, err := data.GetBook(ctx, db, bookID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return nil
+	}
+
+	if book.UserID != pathUser.ID {
+		NotFoundHandler(w, r)
+		return nil
+	}
+
+	return book
+}
+
+// getPathUserBookNote returns the note specified by the noteID URL param. It writes a not found response and returns
+// nil if the note does not exist or does not belong to the book specified by the id URL param.
+func getPathUserBookNote(w http.ResponseWriter, r *http.Request) *data.BookNote {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	noteID := int64URLParam(r, "noteID")
+
+	book := getPathUserBook(w, r)
+	if book == nil {
+		return nil
+	}
+
+	note, err := data.GetBookNote(ctx, db, noteID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return nil
+	}
+
+	if note.BookID != book.ID {
+		NotFoundHandler(w, r)
+		return nil
+	}
+
+	return note
+}
+
+func bookNoteFormFromRequest(r *http.Request) view.BookNoteForm {
+	return view.BookNoteForm{
+		Kind: r.FormValue("kind"),
+		Body: r.FormValue("body"),
+		Page: r.FormValue("page"),
+	}
+}
+
+func BookNoteCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	book := getPathUserBook(w, r)
+	if book == nil {
+		return
+	}
+
+	form := bookNoteFormFromRequest(r)
+	attrs, verr := form.Parse()
+	if verr != nil {
+		renderBookShow(w, r, book, form, verr)
+		return
+	}
+	attrs.BookID = book.ID
+
+	_, err := data.CreateBookNote(ctx, db, attrs)
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			renderBookShow(w, r, book, form, verr)
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, route.BookPath(pathUser.Username, book.ID), http.StatusSeeOther)
+}
+
+func BookNoteEdit(w http.ResponseWriter, r *http.Request) {
+	note := getPathUserBookNote(w, r)
+	if note == nil {
+		return
+	}
+
+	err := view.BookNoteEdit(w, baseViewArgsFromRequest(r), note.BookID, note.ID, view.NewBookNoteForm(note), nil)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+func BookNoteUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	note := getPathUserBookNote(w, r)
+	if note == nil {
+		return
+	}
+
+	form := bookNoteFormFromRequest(r)
+	attrs, verr := form.Parse()
+	if verr != nil {
+		err := view.BookNoteEdit(w, baseViewArgsFromRequest(r), note.BookID, note.ID, form, verr)
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+	attrs.ID = note.ID
+
+	err := data.UpdateBookNote(ctx, db, attrs)
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			err := view.BookNoteEdit(w, baseViewArgsFromRequest(r), note.BookID, note.ID, form, verr)
+			if err != nil {
+				InternalServerErrorHandler(w, r, err)
+			}
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, route.BookPath(pathUser.Username, note.BookID), http.StatusSeeOther)
+}
+
+func BookNoteDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	note := getPathUserBookNote(w, r)
+	if note == nil {
+		return
+	}
+
+	err := data.DeleteBookNote(ctx, db, note.ID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if errors.As(err, &nfErr) {
+			NotFoundHandler(w, r)
+		} else {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+
+	http.Redirect(w, r, route.BookPath(pathUser.Username, note.BookID), http.StatusSeeOther)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+)
+
+// svgWriter is a chart that can be written as SVG.
+type svgWriter interface {
+	WriteSVG(w io.Writer) error
+}
+
+// writeSVG responds with the chart as a standalone SVG image.
+func writeSVG(w http.ResponseWriter, r *http.Request, c svgWriter) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	err := c.WriteSVG(w)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+func BooksPerYearChart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	booksPerYear, err := data.BooksPerYear(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	writeSVG(w, r, view.BooksPerYearChart(booksPerYear))
+}
+
+func BooksPerMonthChart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	period := statsPeriodFromQuery(r.URL.Query(), time.Now())
+	booksPerMonth, err := data.BooksPerMonth(ctx, db, pathUser.ID, period.From, period.To)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	writeSVG(w, r, view.BooksPerMonthChart(booksPerMonth))
+}
+
+func BooksPerFormatChart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	period := statsPeriodFromQuery(r.URL.Query(), time.Now())
+	booksPerFormat, err := data.BooksPerFormat(ctx, db, pathUser.ID, period.From, period.To)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	writeSVG(w, r, view.BooksPerFormatChart(booksPerFormat))
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+func ChartShareTokenShow(w http.ResponseWriter, r *http.Request) {
+	renderChartShareToken(w, r, "")
+}
+
+func renderChartShareToken(w http.ResponseWriter, r *http.Request, newToken string) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	shareToken, err := data.GetChartShareToken(ctx, db, pathUser.ID)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if !errors.As(err, &nfErr) {
+			InternalServerErrorHandler(w, r, err)
+			return
+		}
+	}
+
+	err = view.ChartShareToken(w, baseViewArgsFromRequest(r), shareToken, newToken)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// ChartShareTokenCreate creates or replaces the chart share token and renders the shared chart URLs. This is the only
+// time the token is available.
+func ChartShareTokenCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	token, _, err := data.CreateChartShareToken(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	renderChartShareToken(w, r, token)
+}
+
+func ChartShareTokenDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	err := data.DeleteChartShareToken(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, route.ChartShareTokenPath(pathUser.Username), http.StatusSeeOther)
+}
+
+// chartShareTokenHandler sets the path user to the user whose charts the token URL param shares.
+func chartShareTokenHandler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			db := ctx.Value(RequestDBKey).(dbconn)
+
+			user, err := data.GetUserMinByChartShareToken(ctx, db, chi.URLParam(r, "token"))
+			if err != nil {
+				var nfErr *data.NotFoundError
+				if errors.As(err, &nfErr) {
+					NotFoundHandler(w, r)
+				} else {
+					InternalServerErrorHandler(w, r, err)
+				}
+				return
+			}
+
+			ctx = context.WithValue(ctx, RequestPathUserKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/booklog/data"
+)
+
+// csvExportHeader is the header of a book CSV export. Every book field is exported. importBooksFromCSV matches the
+// same column names so an export can be imported again. The id, insert_time, and update_time columns are ignored on
+// import because new books get a new id and timestamps.
+var csvExportHeader = []string{
+	"id",
+	"title",
+	"author",
+	"status",
+	"start_date",
+	"finish_date",
+	"format",
+	"location",
+	"rating",
+	"review",
+	"tags",
+	"isbn",
+	"page_count",
+	"audio_minutes",
+	"series",
+	"series_position",
+	"insert_time",
+	"update_time",
+}
+
+// bookCSVRecord converts book to a record matching csvExportHeader. Dates are formatted as YYYY-MM-DD and times as
+// RFC 3339. Missing optional values are empty.
+func bookCSVRecord(book *data.Book) []string {
+	formatDate := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+
+	var rating, pageCount, audioMinutes, seriesPosition string
+	if book.Rating != 0 {
+		rating = strconv.FormatFloat(book.Rating, 'f', -1, 64)
+	}
+	if book.PageCount != 0 {
+		pageCount = strconv.FormatInt(int64(book.PageCount), 10)
+	}
+	if book.AudioMinutes != 0 {
+		audioMinutes = strconv.FormatInt(int64(book.AudioMinutes), 10)
+	}
+	if book.SeriesPosition != 0 {
+		seriesPosition = strconv.FormatFloat(book.SeriesPosition, 'f', -1, 64)
+	}
+
+	return []string{
+		strconv.FormatInt(book.ID, 10),
+		book.Title,
+		book.Author,
+		book.Status,
+		formatDate(book.StartDate),
+		formatDate(book.FinishDate),
+		book.Format,
+		book.Location,
+		rating,
+		book.Review,
+		strings.Join(book.Tags, ", "),
+		book.ISBN,
+		pageCount,
+		audioMinutes,
+		book.Series,
+		seriesPosition,
+		book.InsertTime.UTC().Format(time.RFC3339Nano),
+		book.UpdateTime.UTC().Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookCSVRecordRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	book := &data.Book{
+		ID:             42,
+		Title:          "Paradise Lost",
+		Author:         "John Milton",
+		Status:         data.BookStatusFinished,
+		StartDate:      time.Date(2005, 6, 20, 0, 0, 0, 0, time.UTC),
+		FinishDate:     time.Date(2005, 7, 2, 0, 0, 0, 0, time.UTC),
+		Format:         "text",
+		Location:       "Home",
+		Rating:         4.5,
+		Review:         "Long, but worth it.",
+		ISBN:           "9780140424393",
+		PageCount:      453,
+		Series:         "Paradise",
+		SeriesPosition: 1,
+		Tags:           []string{"poetry", "classics"},
+		InsertTime:     time.Date(2005, 7, 2, 10, 30, 0, 0, time.UTC),
+		UpdateTime:     time.Date(2005, 7, 3, 8, 0, 0, 0, time.UTC),
+	}
+
+	record := bookCSVRecord(book)
+	require.Len(t, record, len(csvExportHeader))
+	require.Equal(t, "42", record[0])
+	require.Equal(t, "2005-07-02T10:30:00Z", record[16])
+
+	mapping, err := newCSVColumnMapping(csvExportHeader)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "insert_time", "update_time"}, mapping.unmatchedHeaders(csvExportHeader))
+
+	imported, verr := mapping.form(record).Parse()
+	require.Nil(t, verr)
+	imported.Normalize()
+
+	require.Equal(t, book.Title, imported.Title)
+	require.Equal(t, book.Author, imported.Author)
+	require.Equal(t, book.Status, imported.Status)
+	require.True(t, book.StartDate.Equal(imported.StartDate))
+	require.True(t, book.FinishDate.Equal(imported.FinishDate))
+	require.Equal(t, book.Format, imported.Format)
+	require.Equal(t, book.Location, imported.Location)
+	require.Equal(t, book.Rating, imported.Rating)
+	require.Equal(t, book.Review, imported.Review)
+	require.Equal(t, book.ISBN, imported.ISBN)
+	require.Equal(t, book.PageCount, imported.PageCount)
+	require.Equal(t, book.Series, imported.Series)
+	require.Equal(t, book.SeriesPosition, imported.SeriesPosition)
+	require.ElementsMatch(t, book.Tags, imported.Tags)
+}
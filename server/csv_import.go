@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+// csvImportField is a book form field that can be imported from a CSV column.
+type csvImportField struct {
+	name    string
+	label   string
+	headers []string // normalized header names that match this field
+	set     func(form *view.BookEditForm, value string)
+}
+
+var csvImportFields = []csvImportField{
+	{"title", "Title", []string{"title"}, func(f *view.BookEditForm, v string) { f.Title = v }},
+	{"author", "Author", []string{"author"}, func(f *view.BookEditForm, v string) { f.Author = v }},
+	{"status", "Status", []string{"status"}, func(f *view.BookEditForm, v string) { f.Status = v }},
+	{"startDate", "Start Date", []string{"startdate", "datestarted", "started"}, func(f *view.BookEditForm, v string) { f.StartDate = v }},
+	{"finishDate", "Finish Date", []string{"finishdate", "datefinished", "finished", "dateread"}, func(f *view.BookEditForm, v string) { f.FinishDate = v }},
+	{"format", "Format", []string{"format"}, func(f *view.BookEditForm, v string) { f.Format = v }},
+	{"location", "Location", []string{"location"}, func(f *view.BookEditForm, v string) { f.Location = v }},
+	{"rating", "Rating", []string{"rating"}, func(f *view.BookEditForm, v string) { f.Rating = v }},
+	{"review", "Review", []string{"review"}, func(f *view.BookEditForm, v string) { f.Review = v }},
+	{"tags", "Tags", []string{"tags"}, func(f *view.BookEditForm, v string) { f.Tags = v }},
+	{"isbn", "ISBN", []string{"isbn"}, func(f *view.BookEditForm, v string) { f.ISBN = v }},
+	{"pageCount", "Pages", []string{"pagecount", "pages"}, func(f *view.BookEditForm, v string) { f.PageCount = v }},
+	{"audioMinutes", "Audio Minutes", []string{"audiominutes"}, func(f *view.BookEditForm, v string) { f.AudioMinutes = v }},
+	{"series", "Series", []string{"series"}, func(f *view.BookEditForm, v string) { f.Series = v }},
+	{"seriesPosition", "Series Position", []string{"seriesposition", "seriesnumber"}, func(f *view.BookEditForm, v string) { f.SeriesPosition = v }},
+}
+
+// normalizeCSVHeader lowercases header and removes everything but letters and digits so "Date Finished",
+// "date_finished", and "dateFinished" all match.
+func normalizeCSVHeader(header string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, header)
+}
+
+// csvColumnMapping maps book form field names to CSV column indexes.
+type csvColumnMapping map[string]int
+
+// newCSVColumnMapping matches the columns of header to book form fields by name. The title and author columns are
+// required.
+func newCSVColumnMapping(header []string) (csvColumnMapping, error) {
+	mapping := make(csvColumnMapping)
+	for i, h := range header {
+		nh := normalizeCSVHeader(h)
+		for _, field := range csvImportFields {
+			if _, ok := mapping[field.name]; ok {
+				continue
+			}
+			for _, fh := range field.headers {
+				if nh == fh {
+					mapping[field.name] = i
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{"title", "author"} {
+		if _, ok := mapping[name]; !ok {
+			return nil, errors.Errorf("CSV header must include a %s column", name)
+		}
+	}
+
+	return mapping, nil
+}
+
+// form converts record to a book form. Format defaults to text.
+func (m csvColumnMapping) form(record []string) view.BookEditForm {
+	var form view.BookEditForm
+	m.set(&form, record)
+	if form.Format == "" {
+		form.Format = "text"
+	}
+
+	return form
+}
+
+// updateForm converts record to a form for updating existing. Fields without a matched column keep the values of
+// existing.
+func (m csvColumnMapping) updateForm(existing *data.Book, record []string) view.BookEditForm {
+	form := view.NewBookEditForm(existing)
+	m.set(&form, record)
+
+	return form
+}
+
+// set sets the fields of form that have a matched column to their values in record.
+func (m csvColumnMapping) set(form *view.BookEditForm, record []string) {
+	for _, field := range csvImportFields {
+		if i, ok := m[field.name]; ok && i < len(record) {
+			field.set(form, record[i])
+		}
+	}
+}
+
+// columnMatches describes which header column each field was matched to.
+func (m csvColumnMapping) columnMatches(header []string) []view.CSVColumnMatch {
+	matches := make([]view.CSVColumnMatch, 0, len(csvImportFields))
+	for _, field := range csvImportFields {
+		match := view.CSVColumnMatch{Field: field.label}
+		if i, ok := m[field.name]; ok {
+			match.Header = header[i]
+		}
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// unmatchedHeaders returns the headers that were not matched to any field.
+func (m csvColumnMapping) unmatchedHeaders(header []string) []string {
+	matched := make(map[int]struct{}, len(m))
+	for _, i := range m {
+		matched[i] = struct{}{}
+	}
+
+	var unmatched []string
+	for i, h := range header {
+		if _, ok := matched[i]; !ok && strings.TrimSpace(h) != "" {
+			unmatched = append(unmatched, h)
+		}
+	}
+
+	return unmatched
+}
+
+// Duplicate actions control what an import does with a row that duplicates an existing book.
+const (
+	duplicateSkip   = "skip"
+	duplicateUpdate = "update"
+	duplicateInsert = "insert"
+)
+
+// duplicateActionFromForm returns the duplicate action in value. It defaults to skipping duplicates.
+func duplicateActionFromForm(value string) string {
+	switch value {
+	case duplicateUpdate, duplicateInsert:
+		return value
+	default:
+		return duplicateSkip
+	}
+}
+
+// importBook saves attrs as a new book unless it duplicates an existing book. Duplicates are skipped, update the
+// existing book, or are inserted anyway depending on duplicateAction. update returns the attributes to update a
+// duplicate with. If update is nil the duplicate is updated with attrs. It returns the action taken and the ID of the
+// inserted or updated book. The ID is 0 if the book was skipped.
+func importBook(ctx context.Context, db dbconn, attrs data.Book, duplicateAction string, update func(duplicate *data.Book) (data.Book, error)) (string, int64, error) {
+	if duplicateAction != duplicateInsert {
+		duplicate, err := data.FindDuplicateBook(ctx, db, attrs)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if duplicate != nil {
+			if duplicateAction == duplicateSkip {
+				return duplicateSkip, 0, nil
+			}
+
+			if update != nil {
+				attrs, err = update(duplicate)
+				if err != nil {
+					return "", 0, err
+				}
+				attrs.UserID = duplicate.UserID
+			}
+			attrs.ID = duplicate.ID
+			return duplicateUpdate, duplicate.ID, data.UpdateBook(ctx, db, attrs)
+		}
+	}
+
+	book, err := data.CreateBook(ctx, db, attrs)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return duplicateInsert, book.ID, nil
+}
+
+// recordImportedBook counts the imported record in result by the action importBook took. description identifies the
+// record in skip messages.
+func recordImportedBook(result *view.BookImportResult, description string, action string) {
+	switch action {
+	case duplicateInsert:
+		result.ImportedCount++
+	case duplicateUpdate:
+		result.UpdatedCount++
+	case duplicateSkip:
+		result.Skips = append(result.Skips, description+": duplicate of an existing book")
+	}
+}
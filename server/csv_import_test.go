@@ -0,0 +1,96 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCSVColumnMapping(t *testing.T) {
+	t.Parallel()
+
+	header := []string{"Format", "Date Finished", "Notes", "author", "Title", "page_count"}
+	mapping, err := newCSVColumnMapping(header)
+	require.NoError(t, err)
+
+	form := mapping.form([]string{"audio", "6/17/2019", "ignored", "Adam Zamoyski", "Napoleon", "736"})
+	require.Equal(t, "Napoleon", form.Title)
+	require.Equal(t, "Adam Zamoyski", form.Author)
+	require.Equal(t, "6/17/2019", form.FinishDate)
+	require.Equal(t, "audio", form.Format)
+	require.Equal(t, "736", form.PageCount)
+	require.Equal(t, []string{"Notes"}, mapping.unmatchedHeaders(header))
+
+	_, err = newCSVColumnMapping([]string{"Title", "Date Finished"})
+	require.Error(t, err)
+}
+
+func TestCSVColumnMappingUpdateFormKeepsUnmatchedFields(t *testing.T) {
+	t.Parallel()
+
+	existing := &data.Book{
+		ID:         42,
+		Title:      "Napoleon",
+		Author:     "Adam Zamoyski",
+		Status:     data.BookStatusFinished,
+		FinishDate: time.Date(2019, 6, 17, 0, 0, 0, 0, time.UTC),
+		Format:     "audio",
+		Rating:     4.5,
+		Review:     "Thorough",
+		ISBN:       "9780465055937",
+		PageCount:  736,
+		Tags:       []string{"biography", "history"},
+	}
+
+	mapping, err := newCSVColumnMapping([]string{"Title", "Author", "Date Finished", "Location"})
+	require.NoError(t, err)
+
+	attrs, verr := mapping.updateForm(existing, []string{"Napoleon", "Adam Zamoyski", "6/17/2019", "Library"}).Parse()
+	require.Nil(t, verr)
+	require.Equal(t, "Library", attrs.Location)
+	require.Equal(t, "audio", attrs.Format)
+	require.Equal(t, 4.5, attrs.Rating)
+	require.Equal(t, "Thorough", attrs.Review)
+	require.Equal(t, "9780465055937", attrs.ISBN)
+	require.EqualValues(t, 736, attrs.PageCount)
+	require.Equal(t, existing.FinishDate, attrs.FinishDate)
+
+	attrs.Normalize()
+	require.Equal(t, []string{"biography", "history"}, attrs.Tags)
+}
+
+func TestPreviewBooksFromCSV(t *testing.T) {
+	t.Parallel()
+
+	in := `Title,Author,Date Finished,Format
+Paradise Lost,John Milton,7/2/2005,
+Paradise Regained,,7/10/2005,text
+`
+	preview, err := previewBooksFromCSV(in)
+	require.NoError(t, err)
+	require.Equal(t, 2, preview.RowCount)
+	require.Len(t, preview.Rows, 2)
+	require.Nil(t, preview.Rows[0].Errors)
+	require.Equal(t, "text", preview.Rows[0].Form.Format)
+	require.NotEmpty(t, preview.Rows[1].Errors.Get("author"))
+}
+
+func TestRecordImportedBook(t *testing.T) {
+	t.Parallel()
+
+	result := &view.BookImportResult{}
+	recordImportedBook(result, "row 2 (Paradise Lost)", duplicateInsert)
+	recordImportedBook(result, "row 3 (Paradise Regained)", duplicateUpdate)
+	recordImportedBook(result, "row 4 (Paradise Lost)", duplicateSkip)
+
+	require.Equal(t, 1, result.ImportedCount)
+	require.Equal(t, 1, result.UpdatedCount)
+	require.Equal(t, []string{"row 4 (Paradise Lost): duplicate of an existing book"}, result.Skips)
+
+	require.Equal(t, duplicateSkip, duplicateActionFromForm(""))
+	require.Equal(t, duplicateUpdate, duplicateActionFromForm("update"))
+}
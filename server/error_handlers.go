@@ -22,3 +22,15 @@ func ForbiddenHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusForbidden)
 	fmt.Fprintln(w, "Forbidden")
 }
+
+// startedResponseWriter records whether anything has been written to the response. Once it has, the status is sent and
+// an error can no longer be reported to the client.
+type startedResponseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *startedResponseWriter) Write(b []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
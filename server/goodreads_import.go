@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+// goodreadsShelfStatuses maps Goodreads exclusive shelves to book statuses.
+var goodreadsShelfStatuses = map[string]string{
+	"read":              data.BookStatusFinished,
+	"currently-reading": data.BookStatusReading,
+	"to-read":           data.BookStatusWantToRead,
+}
+
+// importBooksFromGoodreadsCSV imports a Goodreads library export. Columns are matched by header name. Rows that cannot
+// be imported are skipped and reported in the result instead of failing the whole import. Rows that duplicate an
+// existing book are handled according to duplicateAction.
+func importBooksFromGoodreadsCSV(ctx context.Context, db dbconn, ownerID int64, r io.Reader, duplicateAction string) (*view.BookImportResult, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("CSV must have at least 2 rows")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"Title", "Author", "Exclusive Shelf"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Errorf("CSV is missing Goodreads column %q", name)
+		}
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	result := &view.BookImportResult{}
+	for i, record := range records[1:] {
+		rowNum := i + 2
+		get := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		form, skipReason := goodreadsRecordToForm(get)
+		if skipReason != "" {
+			result.Skips = append(result.Skips, fmt.Sprintf("row %d (%s): %s", rowNum, get("Title"), skipReason))
+			continue
+		}
+
+		attrs, verr := form.Parse()
+		if verr != nil {
+			result.Skips = append(result.Skips, fmt.Sprintf("row %d (%s): %v", rowNum, get("Title"), verr))
+			continue
+		}
+		attrs.UserID = ownerID
+
+		action, _, err := importBook(ctx, tx, attrs, duplicateAction, func(duplicate *data.Book) (data.Book, error) {
+			attrs, verr := goodreadsUpdateForm(duplicate, form).Parse()
+			if verr != nil {
+				return data.Book{}, verr
+			}
+			return attrs, nil
+		})
+		if err != nil {
+			var verr validate.Errors
+			if errors.As(err, &verr) {
+				result.Skips = append(result.Skips, fmt.Sprintf("row %d (%s): %v", rowNum, get("Title"), verr))
+				continue
+			}
+			return nil, errors.Errorf("row %d: %w", rowNum, err)
+		}
+		recordImportedBook(result, fmt.Sprintf("row %d (%s)", rowNum, get("Title")), action)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// goodreadsRecordToForm converts a Goodreads export row to a book form. get returns the value of the named column. If
+// the row should not be imported the reason is returned.
+func goodreadsRecordToForm(get func(name string) string) (view.BookEditForm, string) {
+	form := view.BookEditForm{
+		Title:      get("Title"),
+		Author:     get("Author"),
+		FinishDate: get("Date Read"),
+		Format:     "text",
+		PageCount:  get("Number of Pages"),
+	}
+
+	shelf := get("Exclusive Shelf")
+	status, ok := goodreadsShelfStatuses[shelf]
+	if !ok {
+		return form, fmt.Sprintf("unknown shelf %q", shelf)
+	}
+	form.Status = status
+	if status == data.BookStatusFinished && form.FinishDate == "" {
+		return form, "read but missing Date Read"
+	}
+
+	// Goodreads uses 0 for unrated.
+	if rating := get("My Rating"); rating != "0" {
+		form.Rating = rating
+	}
+
+	// Goodreads writes ISBNs as spreadsheet formulas like ="9780140424393" to preserve leading zeros.
+	form.ISBN = strings.Trim(get("ISBN13"), `="`)
+	if form.ISBN == "" {
+		form.ISBN = strings.Trim(get("ISBN"), `="`)
+	}
+
+	var tags []string
+	for _, shelf := range strings.Split(get("Bookshelves"), ",") {
+		shelf = strings.TrimSpace(shelf)
+		if _, ok := goodreadsShelfStatuses[shelf]; ok {
+			continue
+		}
+		tags = append(tags, shelf)
+	}
+	form.Tags = strings.Join(tags, ", ")
+
+	return form, ""
+}
+
+// goodreadsUpdateForm returns a form for updating existing with form from goodreadsRecordToForm. Only the fields
+// Goodreads supplies a value for are overwritten. Format and the fields Goodreads does not export keep the values of
+// existing.
+func goodreadsUpdateForm(existing *data.Book, form view.BookEditForm) view.BookEditForm {
+	update := view.NewBookEditForm(existing)
+	update.Title = form.Title
+	update.Author = form.Author
+	update.Status = form.Status
+	if form.FinishDate != "" {
+		update.FinishDate = form.FinishDate
+	}
+	if form.Rating != "" {
+		update.Rating = form.Rating
+	}
+	if form.ISBN != "" {
+		update.ISBN = form.ISBN
+	}
+	if form.PageCount != "" {
+		update.PageCount = form.PageCount
+	}
+	if form.Tags != "" {
+		update.Tags = form.Tags
+	}
+
+	return update
+}
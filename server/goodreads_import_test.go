@@ -0,0 +1,96 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/stretchr/testify/require"
+)
+
+func goodreadsGetter(row map[string]string) func(string) string {
+	return func(name string) string { return row[name] }
+}
+
+func TestGoodreadsRecordToForm(t *testing.T) {
+	t.Parallel()
+
+	form, skipReason := goodreadsRecordToForm(goodreadsGetter(map[string]string{
+		"Title":           "Paradise Lost",
+		"Author":          "John Milton",
+		"Date Read":       "2019/06/17",
+		"My Rating":       "4",
+		"Exclusive Shelf": "read",
+		"ISBN13":          `="9780140424393"`,
+		"Number of Pages": "453",
+		"Bookshelves":     "poetry, read, classics",
+	}))
+	require.Equal(t, "", skipReason)
+	require.Equal(t, data.BookStatusFinished, form.Status)
+	require.Equal(t, "9780140424393", form.ISBN)
+	require.Equal(t, "poetry, classics", form.Tags)
+
+	book, verr := form.Parse()
+	require.Nil(t, verr)
+	require.Equal(t, 2019, book.FinishDate.Year())
+	require.EqualValues(t, 4, book.Rating)
+	require.EqualValues(t, 453, book.PageCount)
+
+	form, skipReason = goodreadsRecordToForm(goodreadsGetter(map[string]string{
+		"Title":           "Paradise Regained",
+		"Author":          "John Milton",
+		"My Rating":       "0",
+		"Exclusive Shelf": "to-read",
+	}))
+	require.Equal(t, "", skipReason)
+	require.Equal(t, data.BookStatusWantToRead, form.Status)
+	require.Equal(t, "", form.Rating)
+
+	_, skipReason = goodreadsRecordToForm(goodreadsGetter(map[string]string{
+		"Title":           "Samson Agonistes",
+		"Author":          "John Milton",
+		"Exclusive Shelf": "read",
+	}))
+	require.NotEqual(t, "", skipReason)
+}
+
+func TestGoodreadsUpdateFormKeepsFieldsGoodreadsDoesNotSupply(t *testing.T) {
+	t.Parallel()
+
+	existing := &data.Book{
+		Title:        "Paradise Lost",
+		Author:       "John Milton",
+		Status:       data.BookStatusFinished,
+		StartDate:    time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+		FinishDate:   time.Date(2019, 6, 17, 0, 0, 0, 0, time.UTC),
+		Format:       "audio",
+		Location:     "Library",
+		Rating:       3,
+		Review:       "Long",
+		AudioMinutes: 720,
+		Series:       "Milton",
+		Tags:         []string{"poetry"},
+	}
+
+	form, skipReason := goodreadsRecordToForm(goodreadsGetter(map[string]string{
+		"Title":           "Paradise Lost",
+		"Author":          "John Milton",
+		"Date Read":       "2019/06/17",
+		"My Rating":       "4",
+		"Exclusive Shelf": "read",
+	}))
+	require.Equal(t, "", skipReason)
+
+	book, verr := goodreadsUpdateForm(existing, form).Parse()
+	require.Nil(t, verr)
+	require.EqualValues(t, 4, book.Rating)
+	require.Equal(t, "audio", book.Format)
+	require.Equal(t, "Library", book.Location)
+	require.Equal(t, existing.StartDate, book.StartDate)
+	require.Equal(t, "Long", book.Review)
+	require.Equal(t, "Milton", book.Series)
+	require.EqualValues(t, 720, book.AudioMinutes)
+
+	book.Normalize()
+	require.Equal(t, []string{"poetry"}, book.Tags)
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+// bookJSONExportVersion is the version of the JSON export format. It must be incremented when a change to the format
+// would prevent an older export from being imported.
+const bookJSONExportVersion = 1
+
+// bookJSONExport is a JSON export of a user's library. Books use the same representation as the API plus their notes.
+// Books and notes are values so a null entry decodes as an empty, invalid record instead of a nil pointer.
+type bookJSONExport struct {
+	Version    int            `json:"version"`
+	ExportTime time.Time      `json:"exportTime"`
+	Books      []exportedBook `json:"books"`
+}
+
+// exportedBook is a book and its notes in a JSON export.
+type exportedBook struct {
+	apiBook
+	Notes []exportedBookNote `json:"notes"`
+}
+
+// exportedBookNote is the JSON representation of a data.BookNote in an export.
+type exportedBookNote struct {
+	Kind       string    `json:"kind"`
+	Body       string    `json:"body"`
+	Page       int32     `json:"page"`
+	InsertTime time.Time `json:"insertTime"`
+	UpdateTime time.Time `json:"updateTime"`
+}
+
+func newExportedBook(book *data.Book, notes []*data.BookNote) exportedBook {
+	eb := exportedBook{
+		apiBook: *newAPIBook(book),
+		Notes:   make([]exportedBookNote, 0, len(notes)),
+	}
+	for _, note := range notes {
+		eb.Notes = append(eb.Notes, exportedBookNote{
+			Kind:       note.Kind,
+			Body:       note.Body,
+			Page:       note.Page,
+			InsertTime: note.InsertTime,
+			UpdateTime: note.UpdateTime,
+		})
+	}
+
+	return eb
+}
+
+// notes converts the notes of eb to data.BookNotes. BookID is not set. Note errors are added to verr keyed by the note
+// number and field such as "notes.2.body".
+func (eb *exportedBook) notes(verr validate.Errors) []data.BookNote {
+	notes := make([]data.BookNote, 0, len(eb.Notes))
+	for i, en := range eb.Notes {
+		note := data.BookNote{Kind: en.Kind, Body: en.Body, Page: en.Page}
+		note.Normalize()
+		for field, errs := range note.Validate() {
+			for _, err := range errs {
+				verr.Add(fmt.Sprintf("notes.%d.%s", i+1, field), err)
+			}
+		}
+		notes = append(notes, note)
+	}
+
+	return notes
+}
+
+func BookExportJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	export := &bookJSONExport{
+		Version:    bookJSONExportVersion,
+		ExportTime: time.Now().UTC(),
+		Books:      []exportedBook{},
+	}
+
+	notesByBook, err := data.GetBookNotesByUser(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = data.EachBook(ctx, db, pathUser.ID, func(book *data.Book) error {
+		export.Books = append(export.Books, newExportedBook(book, notesByBook[book.ID]))
+		return nil
+	})
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=booklog-%s.json", pathUser.Username))
+	writeJSON(w, r, http.StatusOK, export)
+}
+
+func BookImportJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	r.ParseMultipartForm(10 << 20)
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	duplicateAction := duplicateActionFromForm(r.FormValue("duplicateAction"))
+	result, err := importBooksFromJSON(ctx, db, pathUser.ID, file, duplicateAction)
+	err = view.BookImportCSVForm(w, baseViewArgsFromRequest(r), err, result)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+	}
+}
+
+// importBooksFromJSON imports a JSON export in a single transaction. The id, insertTime, and updateTime of each book
+// and the insertTime and updateTime of each note are ignored. If any book or note is invalid nothing is imported and
+// the error is a view.BookImportErrors describing every invalid book. Books that duplicate an existing book are
+// handled according to duplicateAction. Notes are only imported with books that are inserted so importing the same
+// export again does not duplicate notes.
+func importBooksFromJSON(ctx context.Context, db dbconn, ownerID int64, r io.Reader, duplicateAction string) (*view.BookImportResult, error) {
+	var export bookJSONExport
+	err := json.NewDecoder(r).Decode(&export)
+	if err != nil {
+		return nil, errors.Errorf("invalid JSON: %w", err)
+	}
+
+	if export.Version != bookJSONExportVersion {
+		return nil, errors.Errorf("unsupported export version %d", export.Version)
+	}
+
+	books := make([]data.Book, 0, len(export.Books))
+	bookNotes := make([][]data.BookNote, 0, len(export.Books))
+	var recordErrs view.BookImportErrors
+	for i, eb := range export.Books {
+		attrs, verr := eb.book()
+		if verr == nil {
+			attrs.Normalize()
+			verr = attrs.Validate()
+		}
+		if verr == nil {
+			verr = validate.Errors{}
+		}
+		notes := eb.notes(verr)
+		if len(verr) > 0 {
+			recordErrs = append(recordErrs, view.BookImportRecordError{RecordNum: i + 1, Title: eb.Title, Errors: verr})
+			continue
+		}
+
+		attrs.UserID = ownerID
+		books = append(books, attrs)
+		bookNotes = append(bookNotes, notes)
+	}
+	if recordErrs != nil {
+		return nil, recordErrs
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	result := &view.BookImportResult{}
+	for i, attrs := range books {
+		action, bookID, err := importBook(ctx, tx, attrs, duplicateAction, nil)
+		if err != nil {
+			return nil, errors.Errorf("book %d: %w", i+1, err)
+		}
+		recordImportedBook(result, fmt.Sprintf("book %d (%s)", i+1, attrs.Title), action)
+
+		if action == duplicateInsert {
+			for _, note := range bookNotes[i] {
+				note.BookID = bookID
+				_, err := data.CreateBookNote(ctx, tx, note)
+				if err != nil {
+					return nil, errors.Errorf("book %d: %w", i+1, err)
+				}
+			}
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
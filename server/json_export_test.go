@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportBooksFromJSONRejectsUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := importBooksFromJSON(context.Background(), nil, 1, strings.NewReader(`{"version": 99, "books": []}`), duplicateSkip)
+	require.EqualError(t, err, "unsupported export version 99")
+}
+
+func TestImportBooksFromJSONReportsEveryInvalidBook(t *testing.T) {
+	t.Parallel()
+
+	in := `{
+	"version": 1,
+	"books": [
+		{"title": "Paradise Lost", "author": "John Milton", "status": "finished", "finishDate": "2005-07-02", "format": "text"},
+		{"title": "", "author": "John Milton", "status": "finished", "finishDate": "2005-07-10", "format": "text"},
+		{"title": "Paradise Regained", "author": "John Milton", "status": "finished", "finishDate": "July 10", "format": "text"}
+	]
+}`
+
+	_, err := importBooksFromJSON(context.Background(), nil, 1, strings.NewReader(in), duplicateSkip)
+	require.Error(t, err)
+
+	recordErrs, ok := err.(view.BookImportErrors)
+	require.True(t, ok)
+	require.Len(t, recordErrs, 2)
+	require.Equal(t, 2, recordErrs[0].RecordNum)
+	require.NotEmpty(t, recordErrs[0].Errors.Get("title"))
+	require.Equal(t, 3, recordErrs[1].RecordNum)
+	require.NotEmpty(t, recordErrs[1].Errors.Get("finishDate"))
+}
+
+func TestImportBooksFromJSONReportsInvalidNotes(t *testing.T) {
+	t.Parallel()
+
+	in := `{
+	"version": 1,
+	"books": [
+		{"title": "Paradise Lost", "author": "John Milton", "status": "finished", "finishDate": "2005-07-02", "format": "text", "notes": [
+			{"kind": "quote", "body": "Better to reign in Hell, than serve in Heaven.", "page": 12},
+			{"kind": "note", "body": ""}
+		]}
+	]
+}`
+
+	_, err := importBooksFromJSON(context.Background(), nil, 1, strings.NewReader(in), duplicateSkip)
+	require.Error(t, err)
+
+	recordErrs, ok := err.(view.BookImportErrors)
+	require.True(t, ok)
+	require.Len(t, recordErrs, 1)
+	require.NotEmpty(t, recordErrs[0].Errors.Get("notes.2.body"))
+}
+
+func TestImportBooksFromJSONReportsNullEntries(t *testing.T) {
+	t.Parallel()
+
+	in := `{
+	"version": 1,
+	"books": [
+		null,
+		{"title": "Paradise Lost", "author": "John Milton", "status": "finished", "finishDate": "2005-07-02", "format": "text", "notes": [null]}
+	]
+}`
+
+	_, err := importBooksFromJSON(context.Background(), nil, 1, strings.NewReader(in), duplicateSkip)
+	require.Error(t, err)
+
+	recordErrs, ok := err.(view.BookImportErrors)
+	require.True(t, ok)
+	require.Len(t, recordErrs, 2)
+	require.Equal(t, 1, recordErrs[0].RecordNum)
+	require.NotEmpty(t, recordErrs[0].Errors.Get("title"))
+	require.Equal(t, 2, recordErrs[1].RecordNum)
+	require.NotEmpty(t, recordErrs[1].Errors.Get("notes.1.body"))
+}
+
+func TestExportedBookJSON(t *testing.T) {
+	t.Parallel()
+
+	book := &data.Book{ID: 42, Title: "Paradise Lost", Author: "John Milton", Status: data.BookStatusFinished, Format: "text"}
+	notes := []*data.BookNote{
+		{ID: 7, BookID: 42, Kind: data.BookNoteKindQuote, Body: "Better to reign in Hell, than serve in Heaven.", Page: 12, InsertTime: time.Date(2005, 6, 21, 0, 0, 0, 0, time.UTC)},
+	}
+
+	buf, err := json.Marshal(newExportedBook(book, notes))
+	require.NoError(t, err)
+
+	var eb exportedBook
+	err = json.Unmarshal(buf, &eb)
+	require.NoError(t, err)
+	require.Equal(t, "Paradise Lost", eb.Title)
+	require.Len(t, eb.Notes, 1)
+	require.Equal(t, data.BookNoteKindQuote, eb.Notes[0].Kind)
+	require.EqualValues(t, 12, eb.Notes[0].Page)
+	require.True(t, notes[0].InsertTime.Equal(eb.Notes[0].InsertTime))
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+	"github.com/jackc/booklog/view"
+	errors "golang.org/x/xerrors"
+)
+
+// ReadingGoalEdit shows the reading goal form for the year query param. The year defaults to the current year.
+func ReadingGoalEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	year := time.Now().Year()
+	if y, err := strconv.Atoi(r.URL.Query().Get("year")); err == nil {
+		year = y
+	}
+
+	form := view.ReadingGoalForm{Year: strconv.Itoa(year)}
+	goal, err := data.GetReadingGoal(ctx, db, pathUser.ID, year)
+	if err != nil {
+		var nfErr *data.NotFoundError
+		if !errors.As(err, &nfErr) {
+			InternalServerErrorHandler(w, r, err)
+			return
+		}
+	} else {
+		form.Target = strconv.FormatInt(int64(goal.Target), 10)
+	}
+
+	err = view.ReadingGoalEdit(w, baseViewArgsFromRequest(r), form, nil)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// ReadingGoalUpdate sets or removes the reading goal for a year.
+func ReadingGoalUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	form := view.ReadingGoalForm{
+		Year:   r.FormValue("year"),
+		Target: r.FormValue("target"),
+	}
+	goal, verr := form.Parse()
+	if verr != nil {
+		err := view.ReadingGoalEdit(w, baseViewArgsFromRequest(r), form, verr)
+		if err != nil {
+			InternalServerErrorHandler(w, r, err)
+		}
+		return
+	}
+	goal.UserID = pathUser.ID
+
+	var err error
+	if form.Target == "" {
+		err = data.DeleteReadingGoal(ctx, db, goal.UserID, goal.Year)
+	} else {
+		err = data.SetReadingGoal(ctx, db, goal)
+	}
+	if err != nil {
+		var verr validate.Errors
+		if errors.As(err, &verr) {
+			err := view.ReadingGoalEdit(w, baseViewArgsFromRequest(r), form, verr)
+			if err != nil {
+				InternalServerErrorHandler(w, r, err)
+			}
+			return
+		}
+
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, route.UserHomePath(pathUser.Username), http.StatusSeeOther)
+}
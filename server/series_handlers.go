@@ -0,0 +1,55 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+)
+
+func SeriesIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	series, err := data.GetAllSeries(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = view.SeriesIndex(w, baseViewArgsFromRequest(r), series)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+func SeriesShow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	name, err := unescapedURLParam(r, "name")
+	if err != nil {
+		NotFoundHandler(w, r)
+		return
+	}
+
+	books, err := data.GetBooksBySeries(ctx, db, pathUser.ID, name)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	if len(books) == 0 {
+		NotFoundHandler(w, r)
+		return
+	}
+
+	err = view.SeriesShow(w, baseViewArgsFromRequest(r), data.NewSeries(books[0].Series, books))
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"time"
@@ -31,6 +32,7 @@ const (
 	RequestDBKey
 	RequestSessionKey
 	RequestPathUserKey
+	RequestAPIUserKey
 )
 
 type dbconn interface {
@@ -74,41 +76,93 @@ func Serve(listenAddress string, csrfKey []byte, insecureDevMode bool, cookieHas
 
 	r.Use(middleware.Recoverer)
 
-	CSRF := csrf.Protect(csrfKey, csrf.Secure(!insecureDevMode))
-	r.Use(CSRF)
-
 	dbpool, err := pgxpool.Connect(context.Background(), databaseURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to connect to database")
 	}
 	r.Use(pgxPoolHandler(dbpool))
 
-	r.Use(sessionHandler(securecookie.New(cookieHashKey, cookieBlockKey)))
-
-	r.Method("GET", "/", http.HandlerFunc(RootHandler))
-	r.Method("GET", "/user_registration/new", http.HandlerFunc(UserRegistrationNew))
-	r.Method("POST", "/user_registration", http.HandlerFunc(UserRegistrationCreate))
-
-	r.Method("GET", "/login", http.HandlerFunc(UserLoginForm))
-	r.Method("POST", "/login/handle", http.HandlerFunc(UserLogin))
-
-	r.Method("POST", "/logout", http.HandlerFunc(UserLogout))
-
-	r.Route("/users/{username}", func(r chi.Router) {
-		r.Use(pathUserHandler())
-		r.Use(requireSameSessionUserAndPathUserHandler())
-		r.Method("GET", "/", http.HandlerFunc(UserHome))
-		r.Method("GET", "/books", http.HandlerFunc(BookIndex))
-		r.Method("GET", "/books/new", http.HandlerFunc(BookNew))
-		r.Method("POST", "/books", http.HandlerFunc(BookCreate))
-		r.Method("GET", "/books/{id}/edit", parseInt64URLParam("id")(http.HandlerFunc(BookEdit)))
-		r.Method("GET", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(BookShow)))
-		r.Method("GET", "/books/{id}/confirm_delete", parseInt64URLParam("id")(http.HandlerFunc(BookConfirmDelete)))
-		r.Method("PATCH", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(BookUpdate)))
-		r.Method("DELETE", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(BookDelete)))
-		r.Method("GET", "/books/import_csv/form", http.HandlerFunc(BookImportCSVForm))
-		r.Method("POST", "/books/import_csv", http.HandlerFunc(BookImportCSV))
-		r.Method("GET", "/books.csv", http.HandlerFunc(BookExportCSV))
+	// The API authenticates with tokens instead of session cookies so it is not subject to CSRF protection.
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(apiTokenHandler())
+		r.Method("GET", "/books", http.HandlerFunc(APIBookIndex))
+		r.Method("POST", "/books", http.HandlerFunc(APIBookCreate))
+		r.Method("GET", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(APIBookShow)))
+		r.Method("PATCH", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(APIBookUpdate)))
+		r.Method("DELETE", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(APIBookDelete)))
+	})
+
+	// Shared charts authenticate with a token in the URL instead of a session so they can be embedded in other sites.
+	r.Route("/shared_charts/{token}", func(r chi.Router) {
+		r.Use(chartShareTokenHandler())
+		r.Method("GET", "/books_per_year.svg", http.HandlerFunc(BooksPerYearChart))
+		r.Method("GET", "/books_per_month.svg", http.HandlerFunc(BooksPerMonthChart))
+		r.Method("GET", "/books_per_format.svg", http.HandlerFunc(BooksPerFormatChart))
+	})
+
+	r.Group(func(r chi.Router) {
+		CSRF := csrf.Protect(csrfKey, csrf.Secure(!insecureDevMode))
+		r.Use(CSRF)
+
+		r.Use(sessionHandler(securecookie.New(cookieHashKey, cookieBlockKey)))
+
+		r.Method("GET", "/", http.HandlerFunc(RootHandler))
+		r.Method("GET", "/user_registration/new", http.HandlerFunc(UserRegistrationNew))
+		r.Method("POST", "/user_registration", http.HandlerFunc(UserRegistrationCreate))
+
+		r.Method("GET", "/login", http.HandlerFunc(UserLoginForm))
+		r.Method("POST", "/login/handle", http.HandlerFunc(UserLogin))
+
+		r.Method("POST", "/logout", http.HandlerFunc(UserLogout))
+
+		r.Route("/users/{username}", func(r chi.Router) {
+			r.Use(pathUserHandler())
+			r.Use(requireSameSessionUserAndPathUserHandler())
+			r.Method("GET", "/", http.HandlerFunc(UserHome))
+			r.Method("GET", "/books", http.HandlerFunc(BookIndex))
+			r.Method("GET", "/books/new", http.HandlerFunc(BookNew))
+			r.Method("GET", "/books/want-to-read", http.HandlerFunc(WantToReadBookIndex))
+			r.Method("GET", "/books/reading", http.HandlerFunc(ReadingBookIndex))
+			r.Method("GET", "/books/abandoned", http.HandlerFunc(AbandonedBookIndex))
+			r.Method("GET", "/books/search", http.HandlerFunc(BookSearch))
+			r.Method("POST", "/books", http.HandlerFunc(BookCreate))
+			r.Method("GET", "/books/{id}/edit", parseInt64URLParam("id")(http.HandlerFunc(BookEdit)))
+			r.Method("GET", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(BookShow)))
+			r.Method("GET", "/books/{id}/confirm_delete", parseInt64URLParam("id")(http.HandlerFunc(BookConfirmDelete)))
+			r.Method("PATCH", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(BookUpdate)))
+			r.Method("DELETE", "/books/{id}", parseInt64URLParam("id")(http.HandlerFunc(BookDelete)))
+			r.Method("POST", "/books/{id}/finish", parseInt64URLParam("id")(http.HandlerFunc(BookFinish)))
+			r.Method("POST", "/books/{id}/notes", parseInt64URLParam("id")(http.HandlerFunc(BookNoteCreate)))
+			r.Method("GET", "/books/{id}/notes/{noteID}/edit", parseInt64URLParam("id")(parseInt64URLParam("noteID")(http.HandlerFunc(BookNoteEdit))))
+			r.Method("PATCH", "/books/{id}/notes/{noteID}", parseInt64URLParam("id")(parseInt64URLParam("noteID")(http.HandlerFunc(BookNoteUpdate))))
+			r.Method("DELETE", "/books/{id}/notes/{noteID}", parseInt64URLParam("id")(parseInt64URLParam("noteID")(http.HandlerFunc(BookNoteDelete))))
+			r.Method("GET", "/books/import_csv/form", http.HandlerFunc(BookImportCSVForm))
+			r.Method("POST", "/books/import_csv/preview", http.HandlerFunc(BookImportCSVPreview))
+			r.Method("POST", "/books/import_csv", http.HandlerFunc(BookImportCSV))
+			r.Method("GET", "/books.csv", http.HandlerFunc(BookExportCSV))
+			r.Method("POST", "/books/import_json", http.HandlerFunc(BookImportJSON))
+			r.Method("GET", "/books.json", http.HandlerFunc(BookExportJSON))
+			r.Method("GET", "/stats", http.HandlerFunc(UserStats))
+			r.Method("GET", "/years/{year}", parseInt64URLParam("year")(http.HandlerFunc(YearReview)))
+			r.Method("GET", "/charts/books_per_year.svg", http.HandlerFunc(BooksPerYearChart))
+			r.Method("GET", "/charts/books_per_month.svg", http.HandlerFunc(BooksPerMonthChart))
+			r.Method("GET", "/charts/books_per_format.svg", http.HandlerFunc(BooksPerFormatChart))
+			r.Method("GET", "/chart_share_token", http.HandlerFunc(ChartShareTokenShow))
+			r.Method("POST", "/chart_share_token", http.HandlerFunc(ChartShareTokenCreate))
+			r.Method("DELETE", "/chart_share_token", http.HandlerFunc(ChartShareTokenDelete))
+			r.Method("GET", "/reading_goal/edit", http.HandlerFunc(ReadingGoalEdit))
+			r.Method("POST", "/reading_goal", http.HandlerFunc(ReadingGoalUpdate))
+			r.Method("GET", "/authors", http.HandlerFunc(AuthorIndex))
+			r.Method("GET", "/authors/{id}", parseInt64URLParam("id")(http.HandlerFunc(AuthorShow)))
+			r.Method("GET", "/authors/{id}/edit", parseInt64URLParam("id")(http.HandlerFunc(AuthorEdit)))
+			r.Method("PATCH", "/authors/{id}", parseInt64URLParam("id")(http.HandlerFunc(AuthorUpdate)))
+			r.Method("GET", "/series", http.HandlerFunc(SeriesIndex))
+			r.Method("GET", "/series/{name}", http.HandlerFunc(SeriesShow))
+			r.Method("GET", "/tags/{tag}", http.HandlerFunc(TagShow))
+			r.Method("GET", "/api_tokens", http.HandlerFunc(APITokenIndex))
+			r.Method("POST", "/api_tokens", http.HandlerFunc(APITokenCreate))
+			r.Method("DELETE", "/api_tokens/{id}", parseInt64URLParam("id")(http.HandlerFunc(APITokenDelete)))
+		})
 	})
 
 	fileServer(r, "/static", http.Dir("build/static"))
@@ -291,6 +345,17 @@ func int64URLParam(r *http.Request, name string) int64 {
 	return r.Context().Value(ctxURLParamKey(name)).(int64)
 }
 
+// unescapedURLParam returns the URL parameter name of r. chi matches routes against r.URL.RawPath when it is set, so
+// only then does the parameter need to be unescaped. It returns an error if the parameter is not validly escaped.
+func unescapedURLParam(r *http.Request, name string) (string, error) {
+	param := chi.URLParam(r, name)
+	if r.URL.RawPath == "" {
+		return param, nil
+	}
+
+	return url.PathUnescape(param)
+}
+
 func baseViewArgsFromRequest(r *http.Request) *view.BaseViewArgs {
 	var pathUser *data.UserMin
 	if um, ok := r.Context().Value(RequestPathUserKey).(*data.UserMin); ok {
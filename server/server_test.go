@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/jackc/booklog/route"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnescapedURLParam(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []string{"fantasy", "science fiction", "100%", "a/b", "50%/50%"} {
+		var param string
+		var paramErr error
+		router := chi.NewRouter()
+		router.Get("/users/{username}/tags/{tag}", func(w http.ResponseWriter, r *http.Request) {
+			param, paramErr = unescapedURLParam(r, "tag")
+		})
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", route.TagPath("test", tag), nil))
+		require.NoError(t, paramErr, tag)
+		require.Equal(t, tag, param)
+	}
+}
+
+func TestUnescapedURLParamSeries(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"Discworld", "100% Series", "Rivers of London / Peter Grant"} {
+		var param string
+		var paramErr error
+		router := chi.NewRouter()
+		router.Get("/users/{username}/series/{name}", func(w http.ResponseWriter, r *http.Request) {
+			param, paramErr = unescapedURLParam(r, "name")
+		})
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", route.SeriesPath("test", name), nil))
+		require.NoError(t, paramErr, name)
+		require.Equal(t, name, param)
+	}
+}
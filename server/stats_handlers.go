@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+)
+
+// topAuthorsCount is the number of authors shown on the stats page.
+const topAuthorsCount = 10
+
+func UserStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	topAuthors, err := data.TopAuthors(ctx, db, pathUser.ID, topAuthorsCount)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	formatsPerYear, err := data.FormatsPerYear(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	locationFrequency, err := data.LocationFrequency(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	booksPerMonthOfYear, err := data.BooksPerMonthOfYear(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	readingsPerYear, err := data.ReadingsPerYear(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	err = view.UserStats(w, baseViewArgsFromRequest(r), topAuthors, formatsPerYear, locationFrequency, booksPerMonthOfYear, readingsPerYear)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
+
+// YearReview shows a recap of the books finished in the year URL param.
+func YearReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+	year := int(int64URLParam(r, "year"))
+
+	books, err := data.GetBooksByStatus(ctx, db, pathUser.ID, data.BookStatusFinished)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	yearBookList := &view.YearBookList{Year: year}
+	for _, ybl := range view.NewYearBookLists(books) {
+		if ybl.Year == year {
+			yearBookList = ybl
+			break
+		}
+	}
+
+	review := data.ComputeYearReview(year, yearBookList.Books)
+
+	err = view.YearReview(w, baseViewArgsFromRequest(r), review, yearBookList)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
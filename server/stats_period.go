@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jackc/booklog/view"
+)
+
+// maxStatsPeriodYears limits the length of a date range stats period. The home page charts every day and month of the
+// period so an unbounded range would render millions of elements.
+const maxStatsPeriodYears = 5
+
+// statsPeriodFromQuery returns the stats period selected by query. A year param selects that calendar year. Otherwise
+// from and to params in YYYY-MM-DD format select a date range. A missing or invalid to defaults to today and a missing
+// or invalid from defaults to one year before to. A from more than maxStatsPeriodYears before to is moved up to that
+// limit. The default period is the year ending today.
+func statsPeriodFromQuery(query url.Values, now time.Time) view.StatsPeriod {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if year, err := strconv.Atoi(query.Get("year")); err == nil && year > 0 && year < 10000 {
+		return view.StatsPeriod{
+			From: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC),
+			Year: year,
+		}
+	}
+
+	period := view.StatsPeriod{To: today}
+	if to, err := time.Parse("2006-01-02", query.Get("to")); err == nil {
+		period.To = to
+	}
+
+	period.From = period.To.AddDate(-1, 0, 1)
+	if from, err := time.Parse("2006-01-02", query.Get("from")); err == nil && !from.After(period.To) {
+		period.From = from
+	}
+	if earliest := period.To.AddDate(-maxStatsPeriodYears, 0, 1); period.From.Before(earliest) {
+		period.From = earliest
+	}
+
+	return period
+}
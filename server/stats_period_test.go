@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsPeriodFromQuery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2019, 8, 20, 15, 30, 0, 0, time.Local)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		query string
+		from  time.Time
+		to    time.Time
+		year  int
+	}{
+		{"", date(2018, 8, 21), date(2019, 8, 20), 0},
+		{"year=2017", date(2017, 1, 1), date(2017, 12, 31), 2017},
+		{"year=2017&from=2019-01-01", date(2017, 1, 1), date(2017, 12, 31), 2017},
+		{"from=2019-03-01&to=2019-06-30", date(2019, 3, 1), date(2019, 6, 30), 0},
+		{"from=2019-03-01", date(2019, 3, 1), date(2019, 8, 20), 0},
+		{"to=2018-12-31", date(2018, 1, 1), date(2018, 12, 31), 0},
+		{"from=2019-09-01&to=2019-06-30", date(2018, 7, 1), date(2019, 6, 30), 0},
+		{"year=abc&from=bad", date(2018, 8, 21), date(2019, 8, 20), 0},
+		{"from=2010-01-01&to=2019-06-30", date(2014, 7, 1), date(2019, 6, 30), 0},
+		{"from=0001-01-01&to=9999-12-31", date(9995, 1, 1), date(9999, 12, 31), 0},
+	}
+
+	for _, tt := range tests {
+		query, err := url.ParseQuery(tt.query)
+		require.NoError(t, err)
+
+		period := statsPeriodFromQuery(query, now)
+		require.Equal(t, tt.from, period.From, tt.query)
+		require.Equal(t, tt.to, period.To, tt.query)
+		require.Equal(t, tt.year, period.Year, tt.query)
+	}
+}
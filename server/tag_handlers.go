@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/view"
+)
+
+func TagShow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	db := ctx.Value(RequestDBKey).(dbconn)
+	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
+
+	tag, err := unescapedURLParam(r, "tag")
+	if err != nil {
+		NotFoundHandler(w, r)
+		return
+	}
+
+	books, err := data.GetBooksByTag(ctx, db, pathUser.ID, tag)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	if len(books) == 0 {
+		NotFoundHandler(w, r)
+		return
+	}
+
+	err = view.TagShow(w, baseViewArgsFromRequest(r), tag, books)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/view"
@@ -12,38 +13,79 @@ func UserHome(w http.ResponseWriter, r *http.Request) {
 	db := ctx.Value(RequestDBKey).(dbconn)
 	pathUser := ctx.Value(RequestPathUserKey).(*data.UserMin)
 
+	now := time.Now()
+	period := statsPeriodFromQuery(r.URL.Query(), now)
+
 	booksPerYear, err := data.BooksPerYear(ctx, db, pathUser.ID)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
 	}
 
-	booksPerMonthForLastYear, err := data.BooksPerMonthForLastYear(ctx, db, pathUser.ID)
+	averageDaysPerBook, err := data.AverageDaysPerBook(ctx, db, pathUser.ID, period.From, period.To)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
 	}
 
-	books, err := data.GetAllBooks(ctx, db, pathUser.ID)
+	pagesPerYear, err := data.PagesPerYear(ctx, db, pathUser.ID)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
 	}
 
-	yearBooksLists := make([]*view.YearBookList, 0, len(booksPerYear))
-	var ybl *view.YearBookList
+	hoursListenedPerYear, err := data.HoursListenedPerYear(ctx, db, pathUser.ID)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
 
+	booksPerMonth, err := data.BooksPerMonth(ctx, db, pathUser.ID, period.From, period.To)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	booksPerFormat, err := data.BooksPerFormat(ctx, db, pathUser.ID, period.From, period.To)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	booksPerDay, err := data.BooksPerDay(ctx, db, pathUser.ID, period.From, period.To)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	// The goal is for the selected year or the current year when the period is not a calendar year.
+	goalYear := period.Year
+	if goalYear == 0 {
+		goalYear = now.Year()
+	}
+	readingGoalProgress, err := data.GetReadingGoalProgress(ctx, db, pathUser.ID, goalYear, now)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	books, err := data.GetBooksByStatus(ctx, db, pathUser.ID, data.BookStatusFinished)
+	if err != nil {
+		InternalServerErrorHandler(w, r, err)
+		return
+	}
+
+	readingRecords := data.ComputeReadingRecords(books, now)
+
+	var periodBooks []*data.Book
 	for _, book := range books {
-		year := book.FinishDate.Year()
-		if ybl == nil || year != ybl.Year {
-			ybl = &view.YearBookList{Year: year}
-			yearBooksLists = append(yearBooksLists, ybl)
+		if !book.FinishDate.Before(period.From) && !book.FinishDate.After(period.To) {
+			periodBooks = append(periodBooks, book)
 		}
-
-		ybl.Books = append(ybl.Books, book)
 	}
+	yearBooksLists := view.NewYearBookLists(periodBooks)
 
-	err = view.UserHome(w, baseViewArgsFromRequest(r), yearBooksLists, booksPerYear, booksPerMonthForLastYear)
+	err = view.UserHome(w, baseViewArgsFromRequest(r), yearBooksLists, booksPerYear, averageDaysPerBook, pagesPerYear, hoursListenedPerYear, booksPerMonth, booksPerFormat, booksPerDay, readingRecords, readingGoalProgress, goalYear, period)
 	if err != nil {
 		InternalServerErrorHandler(w, r, err)
 		return
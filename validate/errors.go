@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -40,6 +41,18 @@ func (e Errors) Get(attr string) []error {
 	return e[attr]
 }
 
+// MarshalJSON marshals e as an object of attribute names to arrays of error messages.
+func (e Errors) MarshalJSON() ([]byte, error) {
+	messages := make(map[string][]string, len(e))
+	for attr, errs := range e {
+		for _, err := range errs {
+			messages[attr] = append(messages[attr], err.Error())
+		}
+	}
+
+	return json.Marshal(messages)
+}
+
 type PresenceError struct {
 	attr string
 }
@@ -0,0 +1,22 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jackc/booklog/validate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorsMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	v := validate.New()
+	v.Presence("title", "")
+	v.Add("finishDate", errors.New("cannot be in future"))
+
+	buf, err := json.Marshal(v.Err())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"title":["title cannot be blank"],"finishDate":["cannot be in future"]}`, string(buf))
+}
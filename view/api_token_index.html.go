@@ -0,0 +1,147 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+)
+
+func APITokenIndex(w io.Writer, bva *BaseViewArgs, apiTokens []*data.APIToken, name string, newToken string, verr validate.Errors) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  .new-token code {
+    display: block;
+    font-size: 1.25rem;
+    word-break: break-all;
+  }
+
+  table.api-tokens th, table.api-tokens td {
+    text-align: left;
+    padding: 2px 1rem 2px 0;
+  }
+
+  table.api-tokens td {
+    color: var(--light-text-color);
+  }
+</style>
+
+<div class="card">
+  <header>API Tokens</header>
+
+  <p>API requests to <code>/api/v1/books</code> authenticate with an <code>Authorization: Bearer</code> header containing a token.</p>
+
+  `)
+	if newToken != "" {
+		io.WriteString(w, `
+    <div class="new-token">
+      <p>Copy this token now. It will not be shown again.</p>
+      <code>`)
+		io.WriteString(w, html.EscapeString(newToken))
+		io.WriteString(w, `</code>
+    </div>
+  `)
+	}
+	io.WriteString(w, `
+
+  `)
+	if len(apiTokens) > 0 {
+		io.WriteString(w, `
+    <table class="api-tokens">
+      <tr>
+        <th>Name</th>
+        <th>Created</th>
+        <th>Last Used</th>
+        <th></th>
+      </tr>
+      `)
+		for _, t := range apiTokens {
+			io.WriteString(w, `
+        <tr>
+          <th>`)
+			io.WriteString(w, html.EscapeString(t.Name))
+			io.WriteString(w, `</th>
+          <td>`)
+			io.WriteString(w, html.EscapeString(t.InsertTime.Format("January 2, 2006")))
+			io.WriteString(w, `</td>
+          <td>
+            `)
+			if t.LastUsedTime.IsZero() {
+				io.WriteString(w, `
+              Never
+            `)
+			} else {
+				io.WriteString(w, `
+              `)
+				io.WriteString(w, html.EscapeString(t.LastUsedTime.Format("January 2, 2006")))
+				io.WriteString(w, `
+            `)
+			}
+			io.WriteString(w, `
+          </td>
+          <td>
+            <form action="`)
+			io.WriteString(w, html.EscapeString(route.APITokenPath(bva.PathUser.Username, t.ID)))
+			io.WriteString(w, `" method="post" class="link">
+              <input type="hidden" name="_method" value="DELETE">
+              `)
+			io.WriteString(w, bva.CSRFField)
+			io.WriteString(w, `
+              <button>Revoke</button>
+            </form>
+          </td>
+        </tr>
+      `)
+		}
+		io.WriteString(w, `
+    </table>
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="card">
+  <header>New API Token</header>
+
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.APITokensPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="post">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+
+    <div class="field">
+      <label for="name">Name</label>
+      <input type="text" name="name" id="name" value="`)
+	io.WriteString(w, html.EscapeString(name))
+	io.WriteString(w, `" required>
+      `)
+	if errs, ok := verr["name"]; ok {
+		io.WriteString(w, `
+        `)
+		for _, e := range errs {
+			io.WriteString(w, `
+          <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+        `)
+		}
+		io.WriteString(w, `
+      `)
+	}
+	io.WriteString(w, `
+    </div>
+
+    <button type="submit" class="btn">Create</button>
+  </form>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+)
+
+func AuthorEdit(w io.Writer, bva *BaseViewArgs, authorID int64, form AuthorEditForm, verr validate.Errors) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<div class="card">
+  <header>Edit Author</header>
+
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.AuthorPath(bva.PathUser.Username, authorID)))
+	io.WriteString(w, `" method="post">
+    <input type="hidden" name="_method" value="PATCH">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+
+    <div class="field">
+      <label for="name">Name</label>
+      <input type="text" name="name" id="name" value="`)
+	io.WriteString(w, html.EscapeString(form.Name))
+	io.WriteString(w, `">
+      `)
+	if errs, ok := verr["name"]; ok {
+		io.WriteString(w, `
+        `)
+		for _, e := range errs {
+			io.WriteString(w, `
+          <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+        `)
+		}
+		io.WriteString(w, `
+      `)
+	}
+	io.WriteString(w, `
+    </div>
+
+    <div class="field">
+      <label for="aliases">Aliases</label>
+      <textarea name="aliases" id="aliases" rows="4">`)
+	io.WriteString(w, html.EscapeString(form.Aliases))
+	io.WriteString(w, `</textarea>
+      `)
+	if errs, ok := verr["aliases"]; ok {
+		io.WriteString(w, `
+        `)
+		for _, e := range errs {
+			io.WriteString(w, `
+          <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+        `)
+		}
+		io.WriteString(w, `
+      `)
+	}
+	io.WriteString(w, `
+    </div>
+
+    <p>One alias per line. Books by an alias are listed under this author. Another author whose name or alias matches is merged into this author.</p>
+
+    <button type="submit" class="btn">Save</button>
+  </form>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
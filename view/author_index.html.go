@@ -0,0 +1,69 @@
+package view
+
+import (
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func AuthorIndex(w io.Writer, bva *BaseViewArgs, authors []data.AuthorListItem) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  ol.authors {
+    list-style: none;
+    margin: 0;
+    padding: 0;
+  }
+
+  ol.authors > li {
+    margin: 0.5rem 0;
+  }
+
+  ol.authors .count {
+    color: var(--light-text-color);
+  }
+</style>
+
+<div class="card">
+  <header>Authors</header>
+
+  `)
+	if len(authors) == 0 {
+		io.WriteString(w, `
+    <p>No authors</p>
+  `)
+	} else {
+		io.WriteString(w, `
+    <ol class="authors">
+      `)
+		for _, author := range authors {
+			io.WriteString(w, `
+        <li>
+          <a href="`)
+			io.WriteString(w, html.EscapeString(route.AuthorPath(bva.PathUser.Username, author.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(author.Name))
+			io.WriteString(w, `</a>
+          <span class="count">`)
+			io.WriteString(w, strconv.FormatInt(int64(author.BookCount), 10))
+			io.WriteString(w, `</span>
+        </li>
+      `)
+		}
+		io.WriteString(w, `
+    </ol>
+  `)
+	}
+	io.WriteString(w, `
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
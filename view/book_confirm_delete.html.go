@@ -23,9 +23,19 @@ func BookConfirmDelete(w io.Writer, bva *BaseViewArgs, book *data.Book) error {
 	io.WriteString(w, html.EscapeString(book.Author))
 	io.WriteString(w, `</dd>
     <dt>Finish Date</dt>
-    <dd>`)
-	io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
-	io.WriteString(w, `</dd>
+    `)
+	if book.FinishDate.IsZero() {
+		io.WriteString(w, `
+      <dd class="empty">None</dd>
+    `)
+	} else {
+		io.WriteString(w, `
+      <dd>`)
+		io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
+		io.WriteString(w, `</dd>
+    `)
+	}
+	io.WriteString(w, `
     <dt>Format</dt>
     <dd>`)
 	io.WriteString(w, html.EscapeString(book.Format))
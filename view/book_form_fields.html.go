@@ -4,6 +4,7 @@ import (
 	"html"
 	"io"
 
+	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/validate"
 )
 
@@ -55,6 +56,108 @@ func BookFormFields(w io.Writer, bva *BaseViewArgs, form BookEditForm, verr vali
 	io.WriteString(w, `
 </div>
 
+<div class="field">
+  <label for="series">Series</label>
+  <input type="text" name="series" id="series" value="`)
+	io.WriteString(w, html.EscapeString(form.Series))
+	io.WriteString(w, `" >
+  `)
+	if errs, ok := verr["series"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="seriesPosition">Number in series</label>
+  <input type="number" name="seriesPosition" id="seriesPosition" value="`)
+	io.WriteString(w, html.EscapeString(form.SeriesPosition))
+	io.WriteString(w, `" min="0.1" step="0.1">
+  `)
+	if errs, ok := verr["seriesPosition"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="status">Status</label>
+  <select name="status" id="status">
+    `)
+	for _, status := range data.BookStatuses {
+		io.WriteString(w, `
+      <option value="`)
+		io.WriteString(w, html.EscapeString(status))
+		io.WriteString(w, `" `)
+		if form.Status == status {
+			io.WriteString(w, `selected`)
+		}
+		io.WriteString(w, `>`)
+		io.WriteString(w, html.EscapeString(statusLabel(status)))
+		io.WriteString(w, `</option>
+    `)
+	}
+	io.WriteString(w, `
+  </select>
+  `)
+	if errs, ok := verr["status"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="startDate">Start Date</label>
+  <input type="date" name="startDate" id="startDate" value="`)
+	io.WriteString(w, html.EscapeString(form.StartDate))
+	io.WriteString(w, `" >
+  `)
+	if errs, ok := verr["startDate"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
 <div class="field">
   <label for="finishDate">Finish Date</label>
   <input type="date" name="finishDate" id="finishDate" value="`)
@@ -135,6 +238,155 @@ func BookFormFields(w io.Writer, bva *BaseViewArgs, form BookEditForm, verr vali
 	io.WriteString(w, `
 </div>
 
+<div class="field">
+  <label for="rating">Rating</label>
+  <select name="rating" id="rating">
+    <option value="" `)
+	if form.Rating == "" {
+		io.WriteString(w, `selected`)
+	}
+	io.WriteString(w, `>none</option>
+    `)
+	for _, r := range ratingOptions {
+		io.WriteString(w, `
+      <option `)
+		if form.Rating == r {
+			io.WriteString(w, `selected`)
+		}
+		io.WriteString(w, `>`)
+		io.WriteString(w, html.EscapeString(r))
+		io.WriteString(w, `</option>
+    `)
+	}
+	io.WriteString(w, `
+  </select>
+  `)
+	if errs, ok := verr["rating"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="review">Review</label>
+  <textarea name="review" id="review" rows="6">`)
+	io.WriteString(w, html.EscapeString(form.Review))
+	io.WriteString(w, `</textarea>
+  `)
+	if errs, ok := verr["review"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="isbn">ISBN</label>
+  <input type="text" name="isbn" id="isbn" value="`)
+	io.WriteString(w, html.EscapeString(form.ISBN))
+	io.WriteString(w, `" >
+  `)
+	if errs, ok := verr["isbn"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="pageCount">Pages</label>
+  <input type="number" name="pageCount" id="pageCount" value="`)
+	io.WriteString(w, html.EscapeString(form.PageCount))
+	io.WriteString(w, `" min="1">
+  `)
+	if errs, ok := verr["pageCount"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="audioMinutes">Audio minutes</label>
+  <input type="number" name="audioMinutes" id="audioMinutes" value="`)
+	io.WriteString(w, html.EscapeString(form.AudioMinutes))
+	io.WriteString(w, `" min="1">
+  `)
+	if errs, ok := verr["audioMinutes"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="tags">Tags</label>
+  <input type="text" name="tags" id="tags" value="`)
+	io.WriteString(w, html.EscapeString(form.Tags))
+	io.WriteString(w, `" placeholder="fiction, history, work">
+  `)
+	if errs, ok := verr["tags"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
 <button type="submit" class="btn">Save</button>
 `)
 
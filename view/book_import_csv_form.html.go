@@ -3,41 +3,179 @@ package view
 import (
 	"html"
 	"io"
+	"strconv"
 
 	"github.com/jackc/booklog/route"
 )
 
-func BookImportCSVForm(w io.Writer, bva *BaseViewArgs, importErr error) error {
+func BookImportCSVForm(w io.Writer, bva *BaseViewArgs, importErr error, result *BookImportResult) error {
 	LayoutHeader(w, bva)
 	io.WriteString(w, `
 <div class="card">
-  <header>Import Book CSV</header>
+  <header>Import Books</header>
 
-  <p>CSV must include header row.</p>
-  <p>CSV must include 5 columns in order: title, author, date finished, format, and location.</p>
-
-  <form enctype="multipart/form-data" action="`)
-	io.WriteString(w, html.EscapeString(route.ImportBookCSVPath(bva.PathUser.Username)))
-	io.WriteString(w, `" method="post">
+  `)
+	if result != nil {
+		io.WriteString(w, `
+    <p>Imported `)
+		io.WriteString(w, strconv.FormatInt(int64(result.ImportedCount), 10))
+		io.WriteString(w, ` books.</p>
     `)
-	io.WriteString(w, bva.CSRFField)
+		if result.UpdatedCount > 0 {
+			io.WriteString(w, `
+      <p>Updated `)
+			io.WriteString(w, strconv.FormatInt(int64(result.UpdatedCount), 10))
+			io.WriteString(w, ` existing books.</p>
+    `)
+		}
+		io.WriteString(w, `
+    `)
+		if len(result.Skips) > 0 {
+			io.WriteString(w, `
+      <p>Skipped `)
+			io.WriteString(w, strconv.FormatInt(int64(len(result.Skips)), 10))
+			io.WriteString(w, ` rows:</p>
+      <ul class="skips">
+        `)
+			for _, skip := range result.Skips {
+				io.WriteString(w, `
+          <li>`)
+				io.WriteString(w, html.EscapeString(skip))
+				io.WriteString(w, `</li>
+        `)
+			}
+			io.WriteString(w, `
+      </ul>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
 	io.WriteString(w, `
 
-    `)
+  `)
 	if importErr != nil {
 		io.WriteString(w, `
-      <div class="error">`)
+    <div class="error">`)
 		io.WriteString(w, html.EscapeString(importErr.Error()))
 		io.WriteString(w, `</div>
     `)
+		if recordErrs, ok := importErr.(BookImportErrors); ok {
+			io.WriteString(w, `
+      <ul class="skips">
+        `)
+			for _, re := range recordErrs {
+				io.WriteString(w, `
+          <li>
+            book `)
+				io.WriteString(w, strconv.FormatInt(int64(re.RecordNum), 10))
+				io.WriteString(w, ` (`)
+				io.WriteString(w, html.EscapeString(re.Title))
+				io.WriteString(w, `):
+            `)
+				for attr, errs := range re.Errors {
+					io.WriteString(w, `
+              `)
+					for _, e := range errs {
+						io.WriteString(w, `
+                `)
+						io.WriteString(w, html.EscapeString(attr))
+						io.WriteString(w, ` `)
+						io.WriteString(w, html.EscapeString(e.Error()))
+						io.WriteString(w, `;
+              `)
+					}
+					io.WriteString(w, `
+            `)
+				}
+				io.WriteString(w, `
+          </li>
+        `)
+			}
+			io.WriteString(w, `
+      </ul>
+    `)
+		}
+		io.WriteString(w, `
+  `)
 	}
 	io.WriteString(w, `
 
+  <h2>Booklog CSV</h2>
+
+  <p>CSV must include a header row. Columns are matched by name in any order: title, author, status, start date, finish date, format, location, rating, review, tags, ISBN, pages, audio minutes, series, and series position. Title and author are required. Status defaults to finished and format defaults to text.</p>
+  <p>The CSV is previewed before it is imported.</p>
+
+  <form enctype="multipart/form-data" action="`)
+	io.WriteString(w, html.EscapeString(route.PreviewImportBookCSVPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="post">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+
     <div class="field">
-      <label for="file">Format</label>
+      <label for="file">File</label>
       <input type="file" name="file" id="file" />
     </div>
 
+    <button type="submit">Preview</button>
+  </form>
+
+  <h2>Goodreads</h2>
+
+  <p>Goodreads library exports are matched by column name. Books on the read, currently-reading, and to-read shelves are imported as finished, reading, and want to read. Goodreads bookshelves become tags. Rows that cannot be imported are skipped.</p>
+  <p>A row with the same title, author, and finish date as an existing book is a duplicate.</p>
+
+  <form enctype="multipart/form-data" action="`)
+	io.WriteString(w, html.EscapeString(route.ImportBookCSVPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="post">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+    <input type="hidden" name="source" value="goodreads">
+
+    <div class="field">
+      <label for="goodreadsFile">File</label>
+      <input type="file" name="file" id="goodreadsFile" />
+    </div>
+
+    <div class="field">
+      <label for="goodreadsDuplicateAction">Duplicates</label>
+      <select name="duplicateAction" id="goodreadsDuplicateAction">
+        <option value="skip">Skip</option>
+        <option value="update">Update existing book</option>
+        <option value="insert">Import anyway</option>
+      </select>
+    </div>
+
+    <button type="submit">Import</button>
+  </form>
+
+  <h2>Booklog JSON</h2>
+
+  <p>A JSON export from Booklog is imported in full or not at all. If any book is invalid nothing is imported and every invalid book is listed.</p>
+
+  <form enctype="multipart/form-data" action="`)
+	io.WriteString(w, html.EscapeString(route.ImportBookJSONPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="post">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+
+    <div class="field">
+      <label for="jsonFile">File</label>
+      <input type="file" name="file" id="jsonFile" />
+    </div>
+
+    <div class="field">
+      <label for="jsonDuplicateAction">Duplicates</label>
+      <select name="duplicateAction" id="jsonDuplicateAction">
+        <option value="skip">Skip</option>
+        <option value="update">Update existing book</option>
+        <option value="insert">Import anyway</option>
+      </select>
+    </div>
+
     <button type="submit">Import</button>
   </form>
 </div>
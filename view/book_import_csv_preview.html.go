@@ -0,0 +1,183 @@
+package view
+
+import (
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jackc/booklog/route"
+)
+
+func BookImportCSVPreview(w io.Writer, bva *BaseViewArgs, preview *CSVImportPreview) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  table.preview {
+    border-collapse: collapse;
+    margin-bottom: 1rem;
+  }
+
+  table.preview th, table.preview td {
+    text-align: left;
+    padding: 2px 1rem 2px 0;
+    vertical-align: top;
+  }
+
+  table.preview td.unmatched {
+    color: var(--light-text-color);
+  }
+
+  table.preview .error {
+    color: var(--form-error-color);
+  }
+</style>
+
+<div class="card">
+  <header>Import Preview</header>
+
+  <h2>Columns</h2>
+  <table class="preview">
+    `)
+	for _, m := range preview.ColumnMatches {
+		io.WriteString(w, `
+      <tr>
+        <th>`)
+		io.WriteString(w, html.EscapeString(m.Field))
+		io.WriteString(w, `</th>
+        `)
+		if m.Header == "" {
+			io.WriteString(w, `
+          <td class="unmatched">not in CSV</td>
+        `)
+		} else {
+			io.WriteString(w, `
+          <td>`)
+			io.WriteString(w, html.EscapeString(m.Header))
+			io.WriteString(w, `</td>
+        `)
+		}
+		io.WriteString(w, `
+      </tr>
+    `)
+	}
+	io.WriteString(w, `
+  </table>
+
+  `)
+	if len(preview.UnmatchedHeaders) > 0 {
+		io.WriteString(w, `
+    <p>These columns will be ignored:
+      `)
+		for i, h := range preview.UnmatchedHeaders {
+			if i > 0 {
+				io.WriteString(w, `, `)
+			}
+			io.WriteString(w, html.EscapeString(h))
+		}
+		io.WriteString(w, `
+    </p>
+  `)
+	}
+	io.WriteString(w, `
+
+  <h2>First Rows</h2>
+  <table class="preview">
+    <tr>
+      <th>Row</th>
+      <th>Title</th>
+      <th>Author</th>
+      <th>Status</th>
+      <th>Finish Date</th>
+      <th>Format</th>
+      <th>Problems</th>
+    </tr>
+    `)
+	for _, row := range preview.Rows {
+		io.WriteString(w, `
+      <tr>
+        <td>`)
+		io.WriteString(w, strconv.FormatInt(int64(row.RowNum), 10))
+		io.WriteString(w, `</td>
+        <td>`)
+		io.WriteString(w, html.EscapeString(row.Form.Title))
+		io.WriteString(w, `</td>
+        <td>`)
+		io.WriteString(w, html.EscapeString(row.Form.Author))
+		io.WriteString(w, `</td>
+        <td>`)
+		io.WriteString(w, html.EscapeString(row.Form.Status))
+		io.WriteString(w, `</td>
+        <td>`)
+		io.WriteString(w, html.EscapeString(row.Form.FinishDate))
+		io.WriteString(w, `</td>
+        <td>`)
+		io.WriteString(w, html.EscapeString(row.Form.Format))
+		io.WriteString(w, `</td>
+        <td>
+          `)
+		if row.Duplicate {
+			io.WriteString(w, `
+            <div>duplicate of an existing book</div>
+          `)
+		}
+		io.WriteString(w, `
+          `)
+		for attr, errs := range row.Errors {
+			io.WriteString(w, `
+            `)
+			for _, e := range errs {
+				io.WriteString(w, `
+              <div class="error">`)
+				io.WriteString(w, html.EscapeString(attr))
+				io.WriteString(w, ` `)
+				io.WriteString(w, html.EscapeString(e.Error()))
+				io.WriteString(w, `</div>
+            `)
+			}
+			io.WriteString(w, `
+          `)
+		}
+		io.WriteString(w, `
+        </td>
+      </tr>
+    `)
+	}
+	io.WriteString(w, `
+  </table>
+
+  <p>`)
+	io.WriteString(w, strconv.FormatInt(int64(preview.RowCount), 10))
+	io.WriteString(w, ` rows will be imported. If any row has a problem nothing will be imported. A row with the same title, author, and finish date as an existing book is a duplicate.</p>
+
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.ImportBookCSVPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="post">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+    <input type="hidden" name="csv" value="`)
+	io.WriteString(w, html.EscapeString(preview.CSV))
+	io.WriteString(w, `">
+
+    <div class="field">
+      <label for="duplicateAction">Duplicates</label>
+      <select name="duplicateAction" id="duplicateAction">
+        <option value="skip">Skip</option>
+        <option value="update">Update existing book</option>
+        <option value="insert">Import anyway</option>
+      </select>
+    </div>
+    <button type="submit" class="btn">Import</button>
+  </form>
+
+  <a href="`)
+	io.WriteString(w, html.EscapeString(route.ImportBookCSVFormPath(bva.PathUser.Username)))
+	io.WriteString(w, `">Cancel</a>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
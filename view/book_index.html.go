@@ -5,10 +5,11 @@ import (
 	"io"
 	"strconv"
 
+	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/route"
 )
 
-func BookIndex(w io.Writer, bva *BaseViewArgs, yearBookLists []*YearBookList) error {
+func BookIndex(w io.Writer, bva *BaseViewArgs, params route.BookIndexParams, page *data.BookPage) error {
 	LayoutHeader(w, bva)
 	io.WriteString(w, `
 <style>
@@ -74,75 +75,191 @@ func BookIndex(w io.Writer, bva *BaseViewArgs, yearBookLists []*YearBookList) er
     margin-right: 1rem;
   }
 }
+  form.filter {
+    display: flex;
+    flex-wrap: wrap;
+    align-items: flex-end;
+    margin-bottom: 1rem;
+  }
+
+  form.filter > div {
+    margin: 0 1rem 0.5rem 0;
+  }
+
+  form.filter label {
+    display: block;
+    color: var(--light-text-color);
+  }
+
+  nav.sort, nav.pages {
+    color: var(--light-text-color);
+  }
+
+  nav.sort a, nav.sort strong {
+    margin-left: 0.5rem;
+  }
+
+  nav.pages {
+    display: flex;
+    justify-content: space-between;
+    margin-top: 1rem;
+  }
 </style>
 
 <div class="card">
-  `)
-	for _, ybl := range yearBookLists {
+  <form class="filter" action="`)
+	io.WriteString(w, html.EscapeString(route.BooksPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="get">
+    <input type="hidden" name="sort" value="`)
+	io.WriteString(w, html.EscapeString(params.Sort))
+	io.WriteString(w, `">
+    <div>
+      <label for="author">Author</label>
+      <input type="text" name="author" id="author" value="`)
+	io.WriteString(w, html.EscapeString(params.Author))
+	io.WriteString(w, `">
+    </div>
+    <div>
+      <label for="format">Format</label>
+      <select name="format" id="format">
+        <option value="">Any</option>
+        `)
+	for _, format := range []string{"text", "audio", "video"} {
+		io.WriteString(w, `
+          <option value="`)
+		io.WriteString(w, html.EscapeString(format))
+		io.WriteString(w, `" `)
+		if format == params.Format {
+			io.WriteString(w, `selected`)
+		}
+		io.WriteString(w, `>`)
+		io.WriteString(w, html.EscapeString(format))
+		io.WriteString(w, `</option>
+        `)
+	}
+	io.WriteString(w, `
+      </select>
+    </div>
+    <div>
+      <label for="location">Location</label>
+      <input type="text" name="location" id="location" value="`)
+	io.WriteString(w, html.EscapeString(params.Location))
+	io.WriteString(w, `">
+    </div>
+    <div>
+      <label for="from">Finished from</label>
+      <input type="date" name="from" id="from" value="`)
+	io.WriteString(w, html.EscapeString(params.From))
+	io.WriteString(w, `">
+    </div>
+    <div>
+      <label for="to">Finished to</label>
+      <input type="date" name="to" id="to" value="`)
+	io.WriteString(w, html.EscapeString(params.To))
+	io.WriteString(w, `">
+    </div>
+    <div>
+      <button type="submit">Filter</button>
+      <a href="`)
+	io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, route.BookIndexParams{Sort: params.Sort})))
+	io.WriteString(w, `">Clear</a>
+    </div>
+  </form>
+
+  <nav class="sort">
+    Sort by
+    `)
+	for _, sortOption := range data.BookSorts {
 		io.WriteString(w, `
-    <ol class="years">
-      <li>
-        <h2>`)
-		io.WriteString(w, strconv.FormatInt(int64(ybl.Year), 10))
-		io.WriteString(w, `</h2>
-        <ol class="books">
-          `)
-		for _, book := range ybl.Books {
+      `)
+		if sortOption == params.Sort {
 			io.WriteString(w, `
-            <li>
-              <div class="when-and-how">
-                <time class="finished"
-                  datetime="`)
-			io.WriteString(w, html.EscapeString(book.FinishDate.Format("2006-01-02")))
-			io.WriteString(w, `"
-                  title="`)
-			io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
-			io.WriteString(w, `"
-                >
-                  `)
-			io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2")))
+        <strong>`)
+			io.WriteString(w, html.EscapeString(bookSortLabel(sortOption)))
+			io.WriteString(w, `</strong>
+      `)
+		} else {
 			io.WriteString(w, `
-                </time>
-                <span class="format">
-                  `)
-
-			var icon string
-			switch book.Format {
-			case "audio":
-				icon = "🎧"
-			case "text":
-				icon = "📖"
-			case "video":
-				icon = "📺"
+        <a href="`)
+			io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, params.WithSort(sortOption))))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(bookSortLabel(sortOption)))
+			io.WriteString(w, `</a>
+      `)
+		}
+		io.WriteString(w, `
+    `)
+	}
+	io.WriteString(w, `
+  </nav>
+
+  `)
+	if params.Sort == data.BookSortFinishDate {
+		io.WriteString(w, `
+    `)
+		for _, ybl := range NewYearBookLists(page.Books) {
+			io.WriteString(w, `
+      <ol class="years">
+        <li>
+          <h2><a href="`)
+			io.WriteString(w, html.EscapeString(route.YearReviewPath(bva.PathUser.Username, ybl.Year)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, strconv.FormatInt(int64(ybl.Year), 10))
+			io.WriteString(w, `</a></h2>
+          <ol class="books">
+            `)
+			for _, book := range ybl.Books {
+				io.WriteString(w, `
+              `)
+				BookListItem(w, bva, book, false)
+				io.WriteString(w, `
+            `)
 			}
 			io.WriteString(w, `
-                  `)
-			io.WriteString(w, html.EscapeString(icon))
+          </ol>
+        </li>
+      </ol>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	} else {
+		io.WriteString(w, `
+    <ol class="books">
+      `)
+		for _, book := range page.Books {
 			io.WriteString(w, `
-                </span>
-              </div>
-              <div class="what">
-                <a class="title" href="`)
-			io.WriteString(w, route.BookPath(bva.PathUser.Username, book.ID))
-			io.WriteString(w, `">
-                  `)
-			io.WriteString(w, html.EscapeString(book.Title))
+        `)
+			BookListItem(w, bva, book, true)
 			io.WriteString(w, `
-                </a>
-                <div class="author">`)
-			io.WriteString(w, html.EscapeString(book.Author))
-			io.WriteString(w, `</div>
-              </div>
-            </li>
-          `)
+      `)
 		}
 		io.WriteString(w, `
-        </ol>
-      </li>
     </ol>
   `)
 	}
 	io.WriteString(w, `
+
+  <nav class="pages">
+    `)
+	if params.After != "" {
+		io.WriteString(w, `
+      <a href="`)
+		io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, params.WithAfter(""))))
+		io.WriteString(w, `">First page</a>
+    `)
+	}
+	io.WriteString(w, `
+    `)
+	if page.NextCursor != "" {
+		io.WriteString(w, `
+      <a href="`)
+		io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, params.WithAfter(page.NextCursor))))
+		io.WriteString(w, `">Next page</a>
+    `)
+	}
+	io.WriteString(w, `
+  </nav>
 </div>
 `)
 	LayoutFooter(w, bva)
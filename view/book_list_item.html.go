@@ -0,0 +1,72 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func BookListItem(w io.Writer, bva *BaseViewArgs, book *data.Book, showYear bool) error {
+	io.WriteString(w, `<li>
+  <div class="when-and-how">
+    <time class="finished"
+      datetime="`)
+	io.WriteString(w, html.EscapeString(book.FinishDate.Format("2006-01-02")))
+	io.WriteString(w, `"
+      title="`)
+	io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
+	io.WriteString(w, `"
+    >
+      `)
+	if showYear {
+		io.WriteString(w, `
+        `)
+		io.WriteString(w, html.EscapeString(book.FinishDate.Format("Jan 2, 2006")))
+		io.WriteString(w, `
+      `)
+	} else {
+		io.WriteString(w, `
+        `)
+		io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2")))
+		io.WriteString(w, `
+      `)
+	}
+	io.WriteString(w, `
+    </time>
+    <span class="format">
+      `)
+
+	var icon string
+	switch book.Format {
+	case "audio":
+		icon = "🎧"
+	case "text":
+		icon = "📖"
+	case "video":
+		icon = "📺"
+	}
+	io.WriteString(w, `
+      `)
+	io.WriteString(w, html.EscapeString(icon))
+	io.WriteString(w, `
+    </span>
+  </div>
+  <div class="what">
+    <a class="title" href="`)
+	io.WriteString(w, route.BookPath(bva.PathUser.Username, book.ID))
+	io.WriteString(w, `">
+      `)
+	io.WriteString(w, html.EscapeString(book.Title))
+	io.WriteString(w, `
+    </a>
+    <div class="author">`)
+	io.WriteString(w, html.EscapeString(book.Author))
+	io.WriteString(w, `</div>
+  </div>
+</li>
+`)
+
+	return nil
+}
@@ -4,19 +4,44 @@ import (
 	"html"
 	"io"
 
+	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/route"
 	"github.com/jackc/booklog/validate"
 )
 
-func BookNew(w io.Writer, bva *BaseViewArgs, form BookEditForm, verr validate.Errors) error {
+func BookNew(w io.Writer, bva *BaseViewArgs, form BookEditForm, verr validate.Errors, duplicate *data.Book) error {
 	LayoutHeader(w, bva)
 	io.WriteString(w, `
 <div class="card">
   <header>New Book</header>
 
+  `)
+	if duplicate != nil {
+		io.WriteString(w, `
+    <div class="warning">
+      This looks like a duplicate of <a href="`)
+		io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, duplicate.ID)))
+		io.WriteString(w, `">`)
+		io.WriteString(w, html.EscapeString(duplicate.Title))
+		io.WriteString(w, `</a> by `)
+		io.WriteString(w, html.EscapeString(duplicate.Author))
+		io.WriteString(w, `.
+      Save again to add it anyway.
+    </div>
+  `)
+	}
+	io.WriteString(w, `
+
   <form action="`)
 	io.WriteString(w, html.EscapeString(route.BooksPath(bva.PathUser.Username)))
 	io.WriteString(w, `" method="post">
+    `)
+	if duplicate != nil {
+		io.WriteString(w, `
+      <input type="hidden" name="allowDuplicate" value="true">
+    `)
+	}
+	io.WriteString(w, `
     `)
 	BookFormFields(w, bva, form, verr)
 	io.WriteString(w, `
@@ -0,0 +1,36 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+)
+
+func BookNoteEdit(w io.Writer, bva *BaseViewArgs, bookID int64, noteID int64, form BookNoteForm, verr validate.Errors) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<div class="card">
+  <header>Edit Note</header>
+
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.BookNotePath(bva.PathUser.Username, bookID, noteID)))
+	io.WriteString(w, `" method="post">
+    <input type="hidden" name="_method" value="PATCH">
+    `)
+	BookNoteFields(w, bva, form, verr)
+	io.WriteString(w, `
+    <button type="submit" class="btn">Save</button>
+    <a href="`)
+	io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, bookID)))
+	io.WriteString(w, `">Cancel</a>
+  </form>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
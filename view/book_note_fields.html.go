@@ -0,0 +1,96 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/validate"
+)
+
+func BookNoteFields(w io.Writer, bva *BaseViewArgs, form BookNoteForm, verr validate.Errors) error {
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+
+<div class="field">
+  <label for="kind">Kind</label>
+  <select name="kind" id="kind">
+    <option value="`)
+	io.WriteString(w, html.EscapeString(data.BookNoteKindNote))
+	io.WriteString(w, `" `)
+	if form.Kind == data.BookNoteKindNote {
+		io.WriteString(w, `selected`)
+	}
+	io.WriteString(w, `>Note</option>
+    <option value="`)
+	io.WriteString(w, html.EscapeString(data.BookNoteKindQuote))
+	io.WriteString(w, `" `)
+	if form.Kind == data.BookNoteKindQuote {
+		io.WriteString(w, `selected`)
+	}
+	io.WriteString(w, `>Quote</option>
+  </select>
+  `)
+	if errs, ok := verr["kind"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="body">Text</label>
+  <textarea name="body" id="body" rows="4">`)
+	io.WriteString(w, html.EscapeString(form.Body))
+	io.WriteString(w, `</textarea>
+  `)
+	if errs, ok := verr["body"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+
+<div class="field">
+  <label for="page">Page</label>
+  <input type="number" name="page" id="page" value="`)
+	io.WriteString(w, html.EscapeString(form.Page))
+	io.WriteString(w, `" min="1">
+  `)
+	if errs, ok := verr["page"]; ok {
+		io.WriteString(w, `
+    `)
+		for _, e := range errs {
+			io.WriteString(w, `
+      <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+    `)
+		}
+		io.WriteString(w, `
+  `)
+	}
+	io.WriteString(w, `
+</div>
+`)
+
+	return nil
+}
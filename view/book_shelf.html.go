@@ -0,0 +1,122 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func BookShelf(w io.Writer, bva *BaseViewArgs, status string, books []*data.Book) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  nav.shelves ul {
+    margin: 0 0 1rem 0;
+  }
+
+  nav.shelves li {
+    display: inline;
+    padding-right: 1rem;
+  }
+
+  nav.shelves li.current a {
+    font-weight: bold;
+    color: var(--text-color);
+  }
+
+  ol.books {
+    list-style: none;
+    margin: 0;
+    padding: 0;
+  }
+
+  ol.books > li {
+    margin: 1rem 0;
+  }
+
+  ol.books .author, ol.books .started {
+    color: var(--light-text-color);
+  }
+
+  ol.books > li .title {
+    display: block;
+    font-weight: bold;
+  }
+</style>
+
+<div class="card">
+  <header>`)
+	io.WriteString(w, html.EscapeString(statusLabel(status)))
+	io.WriteString(w, `</header>
+
+  <nav class="shelves">
+    <ul>
+      `)
+	for _, s := range data.BookStatuses {
+		io.WriteString(w, `
+        <li `)
+		if s == status {
+			io.WriteString(w, `class="current"`)
+		}
+		io.WriteString(w, `><a href="`)
+		io.WriteString(w, html.EscapeString(route.ShelfPath(bva.PathUser.Username, s)))
+		io.WriteString(w, `">`)
+		io.WriteString(w, html.EscapeString(statusLabel(s)))
+		io.WriteString(w, `</a></li>
+      `)
+	}
+	io.WriteString(w, `
+    </ul>
+  </nav>
+
+  `)
+	if len(books) == 0 {
+		io.WriteString(w, `
+    <p class="empty">No books on this shelf.</p>
+  `)
+	}
+	io.WriteString(w, `
+
+  <ol class="books">
+    `)
+	for _, book := range books {
+		io.WriteString(w, `
+      <li>
+        <a class="title" href="`)
+		io.WriteString(w, route.BookPath(bva.PathUser.Username, book.ID))
+		io.WriteString(w, `">
+          `)
+		io.WriteString(w, html.EscapeString(book.Title))
+		io.WriteString(w, `
+        </a>
+        <div class="author">`)
+		io.WriteString(w, html.EscapeString(book.Author))
+		io.WriteString(w, `</div>
+        `)
+		if !book.StartDate.IsZero() {
+			io.WriteString(w, `
+          <time class="started" datetime="`)
+			io.WriteString(w, html.EscapeString(book.StartDate.Format("2006-01-02")))
+			io.WriteString(w, `">
+            Started `)
+			io.WriteString(w, html.EscapeString(book.StartDate.Format("January 2, 2006")))
+			io.WriteString(w, `
+          </time>
+        `)
+		}
+		io.WriteString(w, `
+      </li>
+    `)
+	}
+	io.WriteString(w, `
+  </ol>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
@@ -3,14 +3,46 @@ package view
 import (
 	"html"
 	"io"
+	"strconv"
+	"time"
 
 	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
 )
 
-func BookShow(w io.Writer, bva *BaseViewArgs, book *data.Book) error {
+func BookShow(w io.Writer, bva *BaseViewArgs, book *data.Book, readings []*data.Book, notes []*data.BookNote, noteForm BookNoteForm, verr validate.Errors) error {
 	LayoutHeader(w, bva)
 	io.WriteString(w, `
+<style>
+  ol.readings {
+    margin: 0 0 1rem 0;
+  }
+
+  ol.readings .details, ol.notes .details {
+    color: var(--light-text-color);
+  }
+
+  ol.notes {
+    list-style: none;
+    margin: 0 0 1rem 0;
+    padding: 0;
+  }
+
+  ol.notes > li {
+    margin: 1rem 0;
+  }
+
+  ol.notes .body {
+    white-space: pre-wrap;
+  }
+
+  ol.notes blockquote {
+    margin: 0 0 0 1rem;
+    font-style: italic;
+  }
+</style>
+
 <div class="card">
     <dl>
       <dt>Title</dt>
@@ -18,17 +50,109 @@ func BookShow(w io.Writer, bva *BaseViewArgs, book *data.Book) error {
 	io.WriteString(w, html.EscapeString(book.Title))
 	io.WriteString(w, `</dd>
       <dt>Author</dt>
+      `)
+	if len(book.Authors) == 0 {
+		io.WriteString(w, `
+        <dd><a href="`)
+		io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, route.BookIndexParams{Author: book.Author})))
+		io.WriteString(w, `">`)
+		io.WriteString(w, html.EscapeString(book.Author))
+		io.WriteString(w, `</a></dd>
+      `)
+	} else {
+		io.WriteString(w, `
+        <dd>
+          `)
+		for i, author := range book.Authors {
+			io.WriteString(w, `
+            `)
+			if i > 0 {
+				io.WriteString(w, `, `)
+			}
+			io.WriteString(w, `
+            <a href="`)
+			io.WriteString(w, html.EscapeString(route.AuthorPath(bva.PathUser.Username, author.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(author.Name))
+			io.WriteString(w, `</a>
+          `)
+		}
+		io.WriteString(w, `
+        </dd>
+      `)
+	}
+	io.WriteString(w, `
+      `)
+	if book.Series != "" {
+		io.WriteString(w, `
+        <dt>Series</dt>
+        <dd>
+          <a href="`)
+		io.WriteString(w, html.EscapeString(route.SeriesPath(bva.PathUser.Username, book.Series)))
+		io.WriteString(w, `">`)
+		io.WriteString(w, html.EscapeString(book.Series))
+		io.WriteString(w, `</a>
+          `)
+		if book.SeriesPosition != 0 {
+			io.WriteString(w, `
+            #`)
+			io.WriteString(w, html.EscapeString(formatSeriesPosition(book.SeriesPosition)))
+			io.WriteString(w, `
+          `)
+		}
+		io.WriteString(w, `
+        </dd>
+      `)
+	}
+	io.WriteString(w, `
+      <dt>Status</dt>
       <dd>`)
-	io.WriteString(w, html.EscapeString(book.Author))
+	io.WriteString(w, html.EscapeString(statusLabel(book.Status)))
 	io.WriteString(w, `</dd>
+      <dt>Start Date</dt>
+      `)
+	if book.StartDate.IsZero() {
+		io.WriteString(w, `
+        <dd class="empty">Unknown</dd>
+      `)
+	} else {
+		io.WriteString(w, `
+        <dd>`)
+		io.WriteString(w, html.EscapeString(book.StartDate.Format("January 2, 2006")))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
       <dt>Finish Date</dt>
-      <dd>`)
-	io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
-	io.WriteString(w, `</dd>
+      `)
+	if book.FinishDate.IsZero() {
+		io.WriteString(w, `
+        <dd class="empty">None</dd>
+      `)
+	} else {
+		io.WriteString(w, `
+        <dd>`)
+		io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
+      `)
+	if days := book.ReadingDays(); days > 0 {
+		io.WriteString(w, `
+        <dt>Days to Finish</dt>
+        <dd>`)
+		io.WriteString(w, strconv.FormatInt(int64(days), 10))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
       <dt>Format</dt>
-      <dd>`)
+      <dd><a href="`)
+	io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, route.BookIndexParams{Format: book.Format})))
+	io.WriteString(w, `">`)
 	io.WriteString(w, html.EscapeString(book.Format))
-	io.WriteString(w, `</dd>
+	io.WriteString(w, `</a></dd>
       <dt>Location</dt>
       `)
 	if book.Location == "" {
@@ -37,14 +161,176 @@ func BookShow(w io.Writer, bva *BaseViewArgs, book *data.Book) error {
       `)
 	} else {
 		io.WriteString(w, `
-        <dd>`)
+        <dd><a href="`)
+		io.WriteString(w, html.EscapeString(route.BookIndexPath(bva.PathUser.Username, route.BookIndexParams{Location: book.Location})))
+		io.WriteString(w, `">`)
 		io.WriteString(w, html.EscapeString(book.Location))
+		io.WriteString(w, `</a></dd>
+      `)
+	}
+	io.WriteString(w, `
+      <dt>Rating</dt>
+      `)
+	if book.Rating == 0 {
+		io.WriteString(w, `
+        <dd class="empty">None</dd>
+      `)
+	} else {
+		io.WriteString(w, `
+        <dd title="`)
+		io.WriteString(w, html.EscapeString(formatRating(book.Rating)))
+		io.WriteString(w, ` out of 5">`)
+		io.WriteString(w, html.EscapeString(ratingStars(book.Rating)))
 		io.WriteString(w, `</dd>
       `)
 	}
 	io.WriteString(w, `
+      <dt>Review</dt>
+      `)
+	if book.Review == "" {
+		io.WriteString(w, `
+        <dd class="empty">None</dd>
+      `)
+	} else {
+		io.WriteString(w, `
+        <dd class="review">`)
+		io.WriteString(w, html.EscapeString(book.Review))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
+      `)
+	if book.ISBN != "" {
+		io.WriteString(w, `
+        <dt>ISBN</dt>
+        <dd>`)
+		io.WriteString(w, html.EscapeString(book.ISBN))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
+      `)
+	if book.PageCount != 0 {
+		io.WriteString(w, `
+        <dt>Pages</dt>
+        <dd>`)
+		io.WriteString(w, strconv.FormatInt(int64(book.PageCount), 10))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
+      `)
+	if book.AudioMinutes != 0 {
+		io.WriteString(w, `
+        <dt>Length</dt>
+        <dd>`)
+		io.WriteString(w, html.EscapeString(formatMinutes(book.AudioMinutes)))
+		io.WriteString(w, `</dd>
+      `)
+	}
+	io.WriteString(w, `
+      <dt>Tags</dt>
+      `)
+	if len(book.Tags) == 0 {
+		io.WriteString(w, `
+        <dd class="empty">None</dd>
+      `)
+	} else {
+		io.WriteString(w, `
+        <dd class="tags">
+          `)
+		for _, tag := range book.Tags {
+			io.WriteString(w, `
+            <a href="`)
+			io.WriteString(w, html.EscapeString(route.TagPath(bva.PathUser.Username, tag)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(tag))
+			io.WriteString(w, `</a>
+          `)
+		}
+		io.WriteString(w, `
+        </dd>
+      `)
+	}
+	io.WriteString(w, `
     </dl>
 
+    <h2>Readings</h2>
+    <ol class="readings">
+      `)
+	for _, reading := range readings {
+		io.WriteString(w, `
+        <li>
+          `)
+		if reading.ID == book.ID {
+			io.WriteString(w, `
+            <strong>`)
+			io.WriteString(w, html.EscapeString(statusLabel(reading.Status)))
+			io.WriteString(w, `</strong>
+          `)
+		} else {
+			io.WriteString(w, `
+            <a href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, reading.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(statusLabel(reading.Status)))
+			io.WriteString(w, `</a>
+          `)
+		}
+		io.WriteString(w, `
+          <span class="details">
+            `)
+		if !reading.FinishDate.IsZero() {
+			io.WriteString(w, `
+              `)
+			io.WriteString(w, html.EscapeString(reading.FinishDate.Format("January 2, 2006")))
+			io.WriteString(w, `
+            `)
+		} else if !reading.StartDate.IsZero() {
+			io.WriteString(w, `
+              started `)
+			io.WriteString(w, html.EscapeString(reading.StartDate.Format("January 2, 2006")))
+			io.WriteString(w, `
+            `)
+		}
+		io.WriteString(w, `
+            &middot; `)
+		io.WriteString(w, html.EscapeString(reading.Format))
+		io.WriteString(w, `
+            `)
+		if reading.Location != "" {
+			io.WriteString(w, `
+              &middot; `)
+			io.WriteString(w, html.EscapeString(reading.Location))
+			io.WriteString(w, `
+            `)
+		}
+		io.WriteString(w, `
+          </span>
+        </li>
+      `)
+	}
+	io.WriteString(w, `
+    </ol>
+
+    `)
+	if book.Status != data.BookStatusFinished {
+		io.WriteString(w, `
+      <form action="`)
+		io.WriteString(w, html.EscapeString(route.FinishBookPath(bva.PathUser.Username, book.ID)))
+		io.WriteString(w, `" method="post" class="link">
+        `)
+		io.WriteString(w, bva.CSRFField)
+		io.WriteString(w, `
+        <button>Mark as finished</button>
+      </form>
+    `)
+	}
+	io.WriteString(w, `
+
+    <a class="title" href="`)
+	io.WriteString(w, html.EscapeString(route.RereadBookPath(bva.PathUser.Username, book.ID)))
+	io.WriteString(w, `">Read again</a>
     <a class="title" href="`)
 	io.WriteString(w, html.EscapeString(route.EditBookPath(bva.PathUser.Username, book.ID)))
 	io.WriteString(w, `">Edit</a>
@@ -52,6 +338,79 @@ func BookShow(w io.Writer, bva *BaseViewArgs, book *data.Book) error {
 	io.WriteString(w, html.EscapeString(route.BookConfirmDeletePath(bva.PathUser.Username, book.ID)))
 	io.WriteString(w, `">Delete</a>
   </div>
+
+<div class="card">
+  <header>Notes</header>
+
+  `)
+	if len(notes) > 0 {
+		io.WriteString(w, `
+    <ol class="notes">
+      `)
+		for _, note := range notes {
+			io.WriteString(w, `
+        <li>
+          `)
+			if note.Kind == data.BookNoteKindQuote {
+				io.WriteString(w, `
+            <blockquote class="body">`)
+				io.WriteString(w, html.EscapeString(note.Body))
+				io.WriteString(w, `</blockquote>
+          `)
+			} else {
+				io.WriteString(w, `
+            <div class="body">`)
+				io.WriteString(w, html.EscapeString(note.Body))
+				io.WriteString(w, `</div>
+          `)
+			}
+			io.WriteString(w, `
+          <div class="details">
+            `)
+			if note.Page != 0 {
+				io.WriteString(w, `
+              Page `)
+				io.WriteString(w, strconv.FormatInt(int64(note.Page), 10))
+				io.WriteString(w, ` &middot;
+            `)
+			}
+			io.WriteString(w, `
+            <time datetime="`)
+			io.WriteString(w, html.EscapeString(note.InsertTime.Format(time.RFC3339)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(note.InsertTime.Format("January 2, 2006")))
+			io.WriteString(w, `</time>
+            &middot; <a href="`)
+			io.WriteString(w, html.EscapeString(route.EditBookNotePath(bva.PathUser.Username, book.ID, note.ID)))
+			io.WriteString(w, `">Edit</a>
+            <form action="`)
+			io.WriteString(w, html.EscapeString(route.BookNotePath(bva.PathUser.Username, book.ID, note.ID)))
+			io.WriteString(w, `" method="post" class="link">
+              <input type="hidden" name="_method" value="DELETE">
+              `)
+			io.WriteString(w, bva.CSRFField)
+			io.WriteString(w, `
+              <button type="submit">Delete</button>
+            </form>
+          </div>
+        </li>
+      `)
+		}
+		io.WriteString(w, `
+    </ol>
+  `)
+	}
+	io.WriteString(w, `
+
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.BookNotesPath(bva.PathUser.Username, book.ID)))
+	io.WriteString(w, `" method="post">
+    `)
+	BookNoteFields(w, bva, noteForm, verr)
+	io.WriteString(w, `
+    <button type="submit" class="btn">Add Note</button>
+  </form>
+</div>
 `)
 	LayoutFooter(w, bva)
 	io.WriteString(w, `
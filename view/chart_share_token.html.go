@@ -0,0 +1,91 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func ChartShareToken(w io.Writer, bva *BaseViewArgs, shareToken *data.ChartShareToken, newToken string) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  .shared-charts code {
+    display: block;
+    word-break: break-all;
+  }
+</style>
+
+<div class="card">
+  <header>Share Charts</header>
+
+  <p>Shared charts can be viewed without logging in so they can be embedded in other sites such as a wiki. Anyone with a shared chart URL can see the chart.</p>
+
+  `)
+	if newToken != "" {
+		io.WriteString(w, `
+    <div class="shared-charts">
+      <p>Copy these URLs now. They will not be shown again.</p>
+      <p>Books per year <code>`)
+		io.WriteString(w, html.EscapeString(route.SharedBooksPerYearChartPath(newToken)))
+		io.WriteString(w, `</code></p>
+      <p>Books per month <code>`)
+		io.WriteString(w, html.EscapeString(route.SharedBooksPerMonthChartPath(newToken)))
+		io.WriteString(w, `</code></p>
+      <p>Books per format <code>`)
+		io.WriteString(w, html.EscapeString(route.SharedBooksPerFormatChartPath(newToken)))
+		io.WriteString(w, `</code></p>
+    </div>
+  `)
+	}
+	io.WriteString(w, `
+
+  `)
+	if shareToken != nil {
+		io.WriteString(w, `
+    <p>Charts have been shared since `)
+		io.WriteString(w, html.EscapeString(shareToken.InsertTime.Format("January 2, 2006")))
+		io.WriteString(w, `.</p>
+
+    <form action="`)
+		io.WriteString(w, html.EscapeString(route.ChartShareTokenPath(bva.PathUser.Username)))
+		io.WriteString(w, `" method="post">
+      `)
+		io.WriteString(w, bva.CSRFField)
+		io.WriteString(w, `
+      <button type="submit" class="btn">Replace URLs</button>
+    </form>
+
+    <form action="`)
+		io.WriteString(w, html.EscapeString(route.ChartShareTokenPath(bva.PathUser.Username)))
+		io.WriteString(w, `" method="post" class="link">
+      <input type="hidden" name="_method" value="DELETE">
+      `)
+		io.WriteString(w, bva.CSRFField)
+		io.WriteString(w, `
+      <button>Stop sharing</button>
+    </form>
+  `)
+	} else {
+		io.WriteString(w, `
+    <form action="`)
+		io.WriteString(w, html.EscapeString(route.ChartShareTokenPath(bva.PathUser.Username)))
+		io.WriteString(w, `" method="post">
+      `)
+		io.WriteString(w, bva.CSRFField)
+		io.WriteString(w, `
+      <button type="submit" class="btn">Share</button>
+    </form>
+  `)
+	}
+	io.WriteString(w, `
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package view
+
+import (
+	"github.com/jackc/booklog/chart"
+	"github.com/jackc/booklog/data"
+)
+
+// BooksPerYearChart charts books per year from oldest to newest. booksPerYear is ordered newest first as returned by
+// data.BooksPerYear.
+func BooksPerYearChart(booksPerYear []data.BooksPerTimeItem) *chart.BarChart {
+	c := &chart.BarChart{Title: "Books per year"}
+	for i := len(booksPerYear) - 1; i >= 0; i-- {
+		bpt := booksPerYear[i]
+		c.Points = append(c.Points, chart.Point{Label: bpt.Time.Format("2006"), Value: float64(bpt.Count)})
+	}
+	return c
+}
+
+// BooksPerMonthChart charts books per month from oldest to newest. booksPerMonth is ordered newest first as returned
+// by data.BooksPerMonth.
+func BooksPerMonthChart(booksPerMonth []data.BooksPerTimeItem) *chart.LineChart {
+	c := &chart.LineChart{Title: "Books per month"}
+	for i := len(booksPerMonth) - 1; i >= 0; i-- {
+		bpt := booksPerMonth[i]
+		c.Points = append(c.Points, chart.Point{Label: bpt.Time.Format("Jan"), Value: float64(bpt.Count)})
+	}
+	return c
+}
+
+// BooksPerFormatChart charts books per format.
+func BooksPerFormatChart(booksPerFormat []data.FormatCountItem) *chart.BarChart {
+	c := &chart.BarChart{Title: "Books per format"}
+	for _, item := range booksPerFormat {
+		c.Points = append(c.Points, chart.Point{Label: item.Format, Value: float64(item.Count)})
+	}
+	return c
+}
+
+// BooksPerDayHeatmap charts books finished per day. booksPerDay is ordered oldest first as returned by
+// data.BooksPerDay.
+func BooksPerDayHeatmap(booksPerDay []data.BooksPerTimeItem) *chart.Heatmap {
+	h := &chart.Heatmap{Title: "Books finished per day"}
+	for _, bpt := range booksPerDay {
+		h.Days = append(h.Days, chart.Day{Date: bpt.Time, Value: float64(bpt.Count)})
+	}
+	return h
+}
+
+// MonthCountChart charts books per month of the year.
+func MonthCountChart(months []data.MonthCountItem) *chart.BarChart {
+	c := &chart.BarChart{Title: "Books per month"}
+	for _, item := range months {
+		c.Points = append(c.Points, chart.Point{Label: item.Month.String()[:3], Value: float64(item.Count)})
+	}
+	return c
+}
@@ -0,0 +1,76 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/booklog/data"
+)
+
+var ratingOptions = []string{"1", "1.5", "2", "2.5", "3", "3.5", "4", "4.5", "5"}
+
+// formatRating formats rating for use as a form value. An unrated book formats as "".
+func formatRating(rating float64) string {
+	if rating == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(rating, 'f', -1, 64)
+}
+
+// ratingStars renders rating as a string of stars. A half star is shown as "½".
+func ratingStars(rating float64) string {
+	stars := strings.Repeat("★", int(rating))
+	if rating != float64(int(rating)) {
+		stars += "½"
+	}
+	return stars
+}
+
+// statusLabel returns the human readable name of a book status.
+func statusLabel(status string) string {
+	switch status {
+	case data.BookStatusWantToRead:
+		return "Want to Read"
+	case data.BookStatusReading:
+		return "Reading"
+	case data.BookStatusFinished:
+		return "Finished"
+	case data.BookStatusAbandoned:
+		return "Abandoned"
+	default:
+		return status
+	}
+}
+
+// formatSeriesPosition formats a series position such as "2" or "1.5". An unnumbered position formats as "".
+func formatSeriesPosition(position float64) string {
+	if position == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(position, 'f', -1, 64)
+}
+
+// formatMinutes formats a duration in minutes as hours and minutes such as "7h 5m".
+func formatMinutes(minutes int32) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+// bookSortLabel returns the human readable name of a book sort order.
+func bookSortLabel(sort string) string {
+	switch sort {
+	case data.BookSortFinishDate:
+		return "Finish Date"
+	case data.BookSortTitle:
+		return "Title"
+	case data.BookSortAuthor:
+		return "Author"
+	case data.BookSortInsertTime:
+		return "Date Added"
+	default:
+		return sort
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"html"
 	"io"
 
+	"github.com/jackc/booklog/data"
 	"github.com/jackc/booklog/route"
 )
 
@@ -24,6 +25,25 @@ func LayoutHeader(w io.Writer, bva *BaseViewArgs) error {
           `)
 	if bva.PathUser != nil {
 		io.WriteString(w, `
+            <li>
+              <form action="`)
+		io.WriteString(w, html.EscapeString(route.SearchBooksPath(bva.PathUser.Username)))
+		io.WriteString(w, `" method="get" class="search">
+                <input type="search" name="q" placeholder="Search books" aria-label="Search books">
+              </form>
+            </li>
+            <li><a href="`)
+		io.WriteString(w, html.EscapeString(route.ShelfPath(bva.PathUser.Username, data.BookStatusReading)))
+		io.WriteString(w, `">Shelves</a></li>
+            <li><a href="`)
+		io.WriteString(w, html.EscapeString(route.AuthorsPath(bva.PathUser.Username)))
+		io.WriteString(w, `">Authors</a></li>
+            <li><a href="`)
+		io.WriteString(w, html.EscapeString(route.SeriesIndexPath(bva.PathUser.Username)))
+		io.WriteString(w, `">Series</a></li>
+            <li><a href="`)
+		io.WriteString(w, html.EscapeString(route.StatsPath(bva.PathUser.Username)))
+		io.WriteString(w, `">Stats</a></li>
             <li><a href="`)
 		io.WriteString(w, html.EscapeString(route.NewBookPath(bva.PathUser.Username)))
 		io.WriteString(w, `">New Book</a></li>
@@ -32,7 +52,13 @@ func LayoutHeader(w io.Writer, bva *BaseViewArgs) error {
 		io.WriteString(w, `">Import</a></li>
             <li><a href="`)
 		io.WriteString(w, html.EscapeString(route.ExportBookCSVPath(bva.PathUser.Username)))
-		io.WriteString(w, `">Export</a></li>
+		io.WriteString(w, `">Export CSV</a></li>
+            <li><a href="`)
+		io.WriteString(w, html.EscapeString(route.ExportBookJSONPath(bva.PathUser.Username)))
+		io.WriteString(w, `">Export JSON</a></li>
+            <li><a href="`)
+		io.WriteString(w, html.EscapeString(route.APITokensPath(bva.PathUser.Username)))
+		io.WriteString(w, `">API</a></li>
           `)
 	}
 	io.WriteString(w, `
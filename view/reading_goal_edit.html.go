@@ -0,0 +1,79 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/route"
+	"github.com/jackc/booklog/validate"
+)
+
+func ReadingGoalEdit(w io.Writer, bva *BaseViewArgs, form ReadingGoalForm, verr validate.Errors) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<div class="card">
+  <header>Reading Goal</header>
+
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.ReadingGoalPath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="post">
+    `)
+	io.WriteString(w, bva.CSRFField)
+	io.WriteString(w, `
+
+    <div class="field">
+      <label for="year">Year</label>
+      <input type="number" name="year" id="year" value="`)
+	io.WriteString(w, html.EscapeString(form.Year))
+	io.WriteString(w, `">
+      `)
+	if errs, ok := verr["year"]; ok {
+		io.WriteString(w, `
+        `)
+		for _, e := range errs {
+			io.WriteString(w, `
+          <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+        `)
+		}
+		io.WriteString(w, `
+      `)
+	}
+	io.WriteString(w, `
+    </div>
+
+    <div class="field">
+      <label for="target">Books to finish</label>
+      <input type="number" name="target" id="target" min="1" value="`)
+	io.WriteString(w, html.EscapeString(form.Target))
+	io.WriteString(w, `">
+      `)
+	if errs, ok := verr["target"]; ok {
+		io.WriteString(w, `
+        `)
+		for _, e := range errs {
+			io.WriteString(w, `
+          <div class="error">`)
+			io.WriteString(w, html.EscapeString(e.Error()))
+			io.WriteString(w, `</div>
+        `)
+		}
+		io.WriteString(w, `
+      `)
+	}
+	io.WriteString(w, `
+    </div>
+
+    <p>Leave books to finish empty to remove the goal.</p>
+
+    <button type="submit" class="btn">Save</button>
+  </form>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package view
+
+import (
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func SeriesIndex(w io.Writer, bva *BaseViewArgs, series []data.SeriesListItem) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  ol.series {
+    list-style: none;
+    margin: 0;
+    padding: 0;
+  }
+
+  ol.series > li {
+    margin: 0.5rem 0;
+  }
+
+  ol.series .count {
+    color: var(--light-text-color);
+  }
+</style>
+
+<div class="card">
+  <header>Series</header>
+
+  `)
+	if len(series) == 0 {
+		io.WriteString(w, `
+    <p>No series. Set the series of a book when editing it.</p>
+  `)
+	} else {
+		io.WriteString(w, `
+    <ol class="series">
+      `)
+		for _, s := range series {
+			io.WriteString(w, `
+        <li>
+          <a href="`)
+			io.WriteString(w, html.EscapeString(route.SeriesPath(bva.PathUser.Username, s.Name)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(s.Name))
+			io.WriteString(w, `</a>
+          <span class="count">`)
+			io.WriteString(w, strconv.FormatInt(int64(s.ReadCount), 10))
+			io.WriteString(w, ` of `)
+			io.WriteString(w, strconv.FormatInt(int64(s.BookCount), 10))
+			io.WriteString(w, ` read</span>
+        </li>
+      `)
+		}
+		io.WriteString(w, `
+    </ol>
+  `)
+	}
+	io.WriteString(w, `
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
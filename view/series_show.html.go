@@ -0,0 +1,169 @@
+package view
+
+import (
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func SeriesShow(w io.Writer, bva *BaseViewArgs, series *data.Series) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  p.summary, ol.entries .status, ol.entries .position {
+    color: var(--light-text-color);
+  }
+
+  ol.entries {
+    list-style: none;
+    margin: 0;
+    padding: 0;
+  }
+
+  ol.entries > li {
+    display: grid;
+    grid-template-columns: 3rem 1fr;
+    margin: 1rem 0;
+  }
+
+  ol.entries > li.gap {
+    font-style: italic;
+    color: var(--light-text-color);
+  }
+
+  ol.entries > li.read .position::after {
+    content: " ✓";
+  }
+
+  ol.entries .title {
+    font-weight: bold;
+  }
+</style>
+
+<div class="card">
+  <header>`)
+	io.WriteString(w, html.EscapeString(series.Name))
+	io.WriteString(w, `</header>
+
+  <p class="summary">
+    `)
+	io.WriteString(w, strconv.FormatInt(int64(series.ReadCount()), 10))
+	io.WriteString(w, ` of `)
+	io.WriteString(w, strconv.FormatInt(int64(len(series.Entries)), 10))
+	io.WriteString(w, ` read
+    `)
+	if len(series.Gaps) > 0 {
+		io.WriteString(w, `
+      &middot; `)
+		io.WriteString(w, strconv.FormatInt(int64(len(series.Gaps)), 10))
+		io.WriteString(w, ` missing
+    `)
+	}
+	io.WriteString(w, `
+  </p>
+
+  <ol class="entries">
+    `)
+
+	gaps := series.Gaps
+	for _, entry := range series.Entries {
+		for len(gaps) > 0 && float64(gaps[0]) < entry.Position {
+			io.WriteString(w, `
+      <li class="gap">
+        <span class="position">#`)
+			io.WriteString(w, strconv.FormatInt(int64(gaps[0]), 10))
+			io.WriteString(w, `</span>
+        <span>Missing</span>
+      </li>
+    `)
+
+			gaps = gaps[1:]
+		}
+		io.WriteString(w, `
+      <li `)
+		if entry.Read() {
+			io.WriteString(w, `class="read"`)
+		}
+		io.WriteString(w, `>
+        <span class="position">#`)
+		io.WriteString(w, html.EscapeString(formatSeriesPosition(entry.Position)))
+		io.WriteString(w, `</span>
+        <div>
+          `)
+		for _, book := range entry.Books {
+			io.WriteString(w, `
+            <div>
+              <a class="title" href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, book.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(book.Title))
+			io.WriteString(w, `</a>
+              <span class="status">
+                `)
+			if book.FinishDate.IsZero() {
+				io.WriteString(w, `
+                  `)
+				io.WriteString(w, html.EscapeString(statusLabel(book.Status)))
+				io.WriteString(w, `
+                `)
+			} else {
+				io.WriteString(w, `
+                  `)
+				io.WriteString(w, html.EscapeString(statusLabel(book.Status)))
+				io.WriteString(w, ` `)
+				io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
+				io.WriteString(w, `
+                `)
+			}
+			io.WriteString(w, `
+              </span>
+            </div>
+          `)
+		}
+		io.WriteString(w, `
+        </div>
+      </li>
+    `)
+	}
+	io.WriteString(w, `
+  </ol>
+
+  `)
+	if len(series.Unnumbered) > 0 {
+		io.WriteString(w, `
+    <h2>Unnumbered</h2>
+    <ol class="entries">
+      `)
+		for _, book := range series.Unnumbered {
+			io.WriteString(w, `
+        <li>
+          <span class="position"></span>
+          <div>
+            <a class="title" href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, book.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(book.Title))
+			io.WriteString(w, `</a>
+            <span class="status">`)
+			io.WriteString(w, html.EscapeString(statusLabel(book.Status)))
+			io.WriteString(w, `</span>
+          </div>
+        </li>
+      `)
+		}
+		io.WriteString(w, `
+    </ol>
+  `)
+	}
+	io.WriteString(w, `
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package view
+
+import (
+	"html"
+	"io"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func TagShow(w io.Writer, bva *BaseViewArgs, tag string, books []*data.Book) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  ol.books {
+    list-style: none;
+    margin: 0;
+    padding: 0;
+  }
+
+  ol.books > li {
+    margin: 1rem 0;
+  }
+
+  ol.books .author, ol.books .when {
+    color: var(--light-text-color);
+  }
+
+  ol.books > li .title {
+    display: block;
+    font-weight: bold;
+  }
+</style>
+
+<div class="card">
+  <header>`)
+	io.WriteString(w, html.EscapeString(tag))
+	io.WriteString(w, `</header>
+
+  <ol class="books">
+    `)
+	for _, book := range books {
+		io.WriteString(w, `
+      <li>
+        <a class="title" href="`)
+		io.WriteString(w, route.BookPath(bva.PathUser.Username, book.ID))
+		io.WriteString(w, `">
+          `)
+		io.WriteString(w, html.EscapeString(book.Title))
+		io.WriteString(w, `
+        </a>
+        <div class="author">`)
+		io.WriteString(w, html.EscapeString(book.Author))
+		io.WriteString(w, `</div>
+        <div class="when">
+          `)
+		if book.FinishDate.IsZero() {
+			io.WriteString(w, `
+            `)
+			io.WriteString(w, html.EscapeString(statusLabel(book.Status)))
+			io.WriteString(w, `
+          `)
+		} else {
+			io.WriteString(w, `
+            `)
+			io.WriteString(w, html.EscapeString(statusLabel(book.Status)))
+			io.WriteString(w, ` `)
+			io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2, 2006")))
+			io.WriteString(w, `
+          `)
+		}
+		io.WriteString(w, `
+        </div>
+      </li>
+    `)
+	}
+	io.WriteString(w, `
+  </ol>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
@@ -2,6 +2,9 @@ package view
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/booklog/data"
@@ -19,12 +22,129 @@ type YearBookList struct {
 	Books []*data.Book
 }
 
+// NewYearBookLists groups books by finish year. books must be ordered by finish date.
+func NewYearBookLists(books []*data.Book) []*YearBookList {
+	yearBookLists := make([]*YearBookList, 0)
+	var ybl *YearBookList
+
+	for _, book := range books {
+		year := book.FinishDate.Year()
+		if ybl == nil || year != ybl.Year {
+			ybl = &YearBookList{Year: year}
+			yearBookLists = append(yearBookLists, ybl)
+		}
+
+		ybl.Books = append(ybl.Books, book)
+	}
+
+	return yearBookLists
+}
+
+// BookImportResult summarizes an import that skips rows that cannot be imported.
+type BookImportResult struct {
+	ImportedCount int
+	UpdatedCount  int
+	Skips         []string
+}
+
+// BookImportRecordError is the validation error of one record of an import.
+type BookImportRecordError struct {
+	RecordNum int
+	Title     string
+	Errors    validate.Errors
+}
+
+// BookImportErrors is returned by an import that failed because some records are invalid. Nothing is imported.
+type BookImportErrors []BookImportRecordError
+
+func (e BookImportErrors) Error() string {
+	return fmt.Sprintf("%d records are invalid", len(e))
+}
+
+// CSVColumnMatch describes the CSV column matched to a book field. Header is empty if no column matched.
+type CSVColumnMatch struct {
+	Field  string
+	Header string
+}
+
+type BookImportPreviewRow struct {
+	RowNum    int
+	Form      BookEditForm
+	Errors    validate.Errors
+	Duplicate bool // a likely duplicate of an existing book
+}
+
+// CSVImportPreview shows how a CSV will be imported before the import is confirmed.
+type CSVImportPreview struct {
+	CSV              string
+	ColumnMatches    []CSVColumnMatch
+	UnmatchedHeaders []string
+	RowCount         int
+	Rows             []BookImportPreviewRow // only the first rows
+}
+
 type BookEditForm struct {
-	Title      string
-	Author     string
-	FinishDate string
-	Format     string
-	Location   string
+	Title          string
+	Author         string
+	Status         string
+	StartDate      string
+	FinishDate     string
+	Format         string
+	Location       string
+	Rating         string
+	Review         string
+	ISBN           string
+	PageCount      string
+	AudioMinutes   string
+	Series         string
+	SeriesPosition string
+	Tags           string // comma separated
+}
+
+// NewBookEditForm returns a form populated from book.
+func NewBookEditForm(book *data.Book) BookEditForm {
+	form := BookEditForm{
+		Title:    book.Title,
+		Author:   book.Author,
+		Status:   book.Status,
+		Format:   book.Format,
+		Location: book.Location,
+		Rating:   formatRating(book.Rating),
+		Review:   book.Review,
+		ISBN:     book.ISBN,
+		Series:   book.Series,
+		Tags:     strings.Join(book.Tags, ", "),
+	}
+	if !book.StartDate.IsZero() {
+		form.StartDate = book.StartDate.Format("2006-01-02")
+	}
+	if !book.FinishDate.IsZero() {
+		form.FinishDate = book.FinishDate.Format("2006-01-02")
+	}
+	if book.PageCount != 0 {
+		form.PageCount = strconv.FormatInt(int64(book.PageCount), 10)
+	}
+	if book.AudioMinutes != 0 {
+		form.AudioMinutes = strconv.FormatInt(int64(book.AudioMinutes), 10)
+	}
+	if book.SeriesPosition != 0 {
+		form.SeriesPosition = formatSeriesPosition(book.SeriesPosition)
+	}
+
+	return form
+}
+
+// NewRereadBookEditForm returns a form for reading book again. It keeps the details of the work and the last format and
+// location, but not the dates, rating, or review of the previous reading.
+func NewRereadBookEditForm(book *data.Book) BookEditForm {
+	form := NewBookEditForm(book)
+	form.Status = data.BookStatusReading
+	form.StartDate = ""
+	form.FinishDate = ""
+	form.Rating = ""
+	form.Review = ""
+
+	return form
 }
 
 func (f BookEditForm) Parse() (data.Book, validate.Errors) {
@@ -32,26 +152,192 @@ func (f BookEditForm) Parse() (data.Book, validate.Errors) {
 	book := data.Book{
 		Title:    f.Title,
 		Author:   f.Author,
+		Status:   f.Status,
 		Format:   f.Format,
 		Location: f.Location,
+		Review:   f.Review,
+		ISBN:     f.ISBN,
+		Series:   f.Series,
+		Tags:     strings.Split(f.Tags, ","),
 	}
 	v := validate.New()
 
-	dateFormats := []string{"2006-01-02", "1/2/2006", "1/2/06"}
+	if book.Status == "" {
+		book.Status = data.BookStatusFinished
+	}
+
+	if f.StartDate != "" {
+		book.StartDate, err = parseDate(f.StartDate)
+		if err != nil {
+			v.Add("startDate", errors.New("is not a date"))
+		}
+	}
+
+	if f.FinishDate != "" {
+		book.FinishDate, err = parseDate(f.FinishDate)
+		if err != nil {
+			v.Add("finishDate", errors.New("is not a date"))
+		}
+	}
+
+	if f.Rating != "" {
+		book.Rating, err = strconv.ParseFloat(f.Rating, 64)
+		if err != nil {
+			v.Add("rating", errors.New("is not a number"))
+		}
+	}
 
+	if f.PageCount != "" {
+		var n int64
+		n, err = strconv.ParseInt(f.PageCount, 10, 32)
+		if err != nil {
+			v.Add("pageCount", errors.New("is not a number"))
+		}
+		book.PageCount = int32(n)
+	}
+
+	if f.AudioMinutes != "" {
+		var n int64
+		n, err = strconv.ParseInt(f.AudioMinutes, 10, 32)
+		if err != nil {
+			v.Add("audioMinutes", errors.New("is not a number"))
+		}
+		book.AudioMinutes = int32(n)
+	}
+
+	if f.SeriesPosition != "" {
+		book.SeriesPosition, err = strconv.ParseFloat(f.SeriesPosition, 64)
+		if err != nil {
+			v.Add("seriesPosition", errors.New("is not a number"))
+		}
+	}
+
+	if v.Err() != nil {
+		return book, v.Err().(validate.Errors)
+	}
+
+	return book, nil
+}
+
+// parseDate parses s in any of the date formats accepted from forms and CSV imports.
+func parseDate(s string) (time.Time, error) {
+	dateFormats := []string{"2006-01-02", "1/2/2006", "1/2/06", "2006/01/02"}
+
+	var t time.Time
+	var err error
 	for _, df := range dateFormats {
-		book.FinishDate, err = time.Parse(df, f.FinishDate)
+		t, err = time.Parse(df, s)
 		if err == nil {
 			break
 		}
 	}
+
+	return t, err
+}
+
+// AuthorEditForm renames an author and sets its aliases. Aliases has one alias per line.
+type AuthorEditForm struct {
+	Name    string
+	Aliases string
+}
+
+func NewAuthorEditForm(author *data.Author) AuthorEditForm {
+	return AuthorEditForm{
+		Name:    author.Name,
+		Aliases: strings.Join(author.Aliases, "\n"),
+	}
+}
+
+func (f AuthorEditForm) Parse() data.Author {
+	return data.Author{
+		Name:    f.Name,
+		Aliases: strings.Split(f.Aliases, "\n"),
+	}
+}
+
+// BookNoteForm adds or edits a note or quote of a book.
+type BookNoteForm struct {
+	Kind string
+	Body string
+	Page string
+}
+
+func NewBookNoteForm(note *data.BookNote) BookNoteForm {
+	form := BookNoteForm{
+		Kind: note.Kind,
+		Body: note.Body,
+	}
+	if note.Page != 0 {
+		form.Page = strconv.FormatInt(int64(note.Page), 10)
+	}
+
+	return form
+}
+
+func (f BookNoteForm) Parse() (data.BookNote, validate.Errors) {
+	note := data.BookNote{
+		Kind: f.Kind,
+		Body: f.Body,
+	}
+	v := validate.New()
+
+	if f.Page != "" {
+		n, err := strconv.ParseInt(f.Page, 10, 32)
+		if err != nil {
+			v.Add("page", errors.New("is not a number"))
+		}
+		note.Page = int32(n)
+	}
+
+	if v.Err() != nil {
+		return note, v.Err().(validate.Errors)
+	}
+
+	return note, nil
+}
+
+// ReadingGoalForm sets the reading goal for a year. An empty target removes the goal.
+type ReadingGoalForm struct {
+	Year   string
+	Target string
+}
+
+func (f ReadingGoalForm) Parse() (data.ReadingGoal, validate.Errors) {
+	var goal data.ReadingGoal
+	v := validate.New()
+
+	year, err := strconv.ParseInt(f.Year, 10, 32)
 	if err != nil {
-		v.Add("finishDate", errors.New("is not a date"))
+		v.Add("year", errors.New("is not a number"))
+	}
+	goal.Year = int(year)
+
+	if f.Target != "" {
+		target, err := strconv.ParseInt(f.Target, 10, 32)
+		if err != nil {
+			v.Add("target", errors.New("is not a number"))
+		}
+		goal.Target = int32(target)
 	}
 
 	if v.Err() != nil {
-		return book, v.Err().(validate.Errors)
+		return goal, v.Err().(validate.Errors)
 	}
 
-	return book, nil
+	return goal, nil
+}
+
+// StatsPeriod is the range of finish dates the stats on the user home page are computed for.
+type StatsPeriod struct {
+	From time.Time
+	To   time.Time
+	Year int // the selected calendar year; 0 if the period is not a calendar year
+}
+
+// Label describes the period.
+func (p StatsPeriod) Label() string {
+	if p.Year != 0 {
+		return strconv.Itoa(p.Year)
+	}
+	return p.From.Format("January 2, 2006") + " to " + p.To.Format("January 2, 2006")
 }
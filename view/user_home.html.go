@@ -14,7 +14,16 @@ func UserHome(
 	bva *BaseViewArgs,
 	yearBookLists []*YearBookList,
 	booksPerYear []data.BooksPerTimeItem,
-	booksPerMonthForLastYear []data.BooksPerTimeItem,
+	averageDaysPerBook float64,
+	pagesPerYear []data.PagesPerTimeItem,
+	hoursListenedPerYear []data.HoursPerTimeItem,
+	booksPerMonth []data.BooksPerTimeItem,
+	booksPerFormat []data.FormatCountItem,
+	booksPerDay []data.BooksPerTimeItem,
+	readingRecords *data.ReadingRecords,
+	readingGoalProgress *data.ReadingGoalProgress,
+	goalYear int,
+	period StatsPeriod,
 ) error {
 	LayoutHeader(w, bva)
 	io.WriteString(w, `
@@ -80,6 +89,48 @@ func UserHome(
     padding: 2px 0;
   }
 
+  .books-per-time .average-days {
+    color: var(--light-text-color);
+    margin: 1rem 0 0 0;
+  }
+
+  .chart svg {
+    max-width: 100%;
+    height: auto;
+  }
+
+  .records h2 {
+    margin: 0 0 1rem 0;
+  }
+
+  .records dt {
+    color: var(--light-text-color);
+  }
+
+  .records dd {
+    margin: 0 0 0.5rem 0;
+  }
+
+  .period label {
+    color: var(--light-text-color);
+  }
+
+  .activity h2 {
+    margin: 0 0 1rem 0;
+  }
+
+  .reading-goal h2 {
+    margin: 0 0 1rem 0;
+  }
+
+  .reading-goal progress {
+    width: 100%;
+  }
+
+  .reading-goal .pace {
+    color: var(--light-text-color);
+  }
+
 @media (max-width: 32rem) {
   .stats {
     grid-template-columns: 1fr;
@@ -117,18 +168,130 @@ func UserHome(
 }
 </style>
 
+<div class="card period">
+  <form action="`)
+	io.WriteString(w, html.EscapeString(route.UserHomePath(bva.PathUser.Username)))
+	io.WriteString(w, `" method="get">
+    <label for="year">Stats for</label>
+    <select name="year" id="year">
+      <option value="">Date range</option>
+      `)
+	for _, bpt := range booksPerYear {
+		io.WriteString(w, `
+        <option value="`)
+		io.WriteString(w, strconv.FormatInt(int64(bpt.Time.Year()), 10))
+		io.WriteString(w, `" `)
+		if bpt.Time.Year() == period.Year {
+			io.WriteString(w, `selected`)
+		}
+		io.WriteString(w, `>`)
+		io.WriteString(w, strconv.FormatInt(int64(bpt.Time.Year()), 10))
+		io.WriteString(w, `</option>
+      `)
+	}
+	io.WriteString(w, `
+    </select>
+    <input type="date" name="from" aria-label="From" value="`)
+	io.WriteString(w, html.EscapeString(period.From.Format("2006-01-02")))
+	io.WriteString(w, `">
+    to
+    <input type="date" name="to" aria-label="To" value="`)
+	io.WriteString(w, html.EscapeString(period.To.Format("2006-01-02")))
+	io.WriteString(w, `">
+    <button type="submit">Show</button>
+  </form>
+  <a href="`)
+	io.WriteString(w, html.EscapeString(route.ChartShareTokenPath(bva.PathUser.Username)))
+	io.WriteString(w, `">Share charts</a>
+</div>
+
+<div class="card activity">
+  <h2>`)
+	io.WriteString(w, html.EscapeString(period.Label()))
+	io.WriteString(w, `</h2>
+
+  <div class="chart">`)
+	BooksPerDayHeatmap(booksPerDay).WriteSVG(w)
+	io.WriteString(w, `</div>
+</div>
+
 <div class="stats">
+  <div class="card reading-goal">
+    <h2>`)
+	io.WriteString(w, strconv.FormatInt(int64(goalYear), 10))
+	io.WriteString(w, ` Goal</h2>
+
+    `)
+	if readingGoalProgress == nil {
+		io.WriteString(w, `
+      <p><a href="`)
+		io.WriteString(w, html.EscapeString(route.EditReadingGoalPath(bva.PathUser.Username, goalYear)))
+		io.WriteString(w, `">Set a reading goal</a></p>
+    `)
+	} else {
+		io.WriteString(w, `
+      <p>`)
+		io.WriteString(w, strconv.FormatInt(int64(readingGoalProgress.FinishedCount), 10))
+		io.WriteString(w, ` of `)
+		io.WriteString(w, strconv.FormatInt(int64(readingGoalProgress.Target), 10))
+		io.WriteString(w, ` books finished (`)
+		io.WriteString(w, html.EscapeString(strconv.FormatFloat(readingGoalProgress.Percent(), 'f', 0, 64)))
+		io.WriteString(w, `%)</p>
+      <progress max="`)
+		io.WriteString(w, strconv.FormatInt(int64(readingGoalProgress.Target), 10))
+		io.WriteString(w, `" value="`)
+		io.WriteString(w, strconv.FormatInt(int64(readingGoalProgress.FinishedCount), 10))
+		io.WriteString(w, `"></progress>
+      `)
+
+		ahead := readingGoalProgress.AheadOfPace()
+		io.WriteString(w, `
+      <p class="pace">
+        `)
+		if ahead >= 0.5 {
+			io.WriteString(w, `
+          `)
+			io.WriteString(w, html.EscapeString(strconv.FormatFloat(ahead, 'f', 1, 64)))
+			io.WriteString(w, ` books ahead of pace
+        `)
+		} else if ahead <= -0.5 {
+			io.WriteString(w, `
+          `)
+			io.WriteString(w, html.EscapeString(strconv.FormatFloat(-ahead, 'f', 1, 64)))
+			io.WriteString(w, ` books behind pace
+        `)
+		} else {
+			io.WriteString(w, `
+          On pace
+        `)
+		}
+		io.WriteString(w, `
+      </p>
+      <p><a href="`)
+		io.WriteString(w, html.EscapeString(route.EditReadingGoalPath(bva.PathUser.Username, goalYear)))
+		io.WriteString(w, `">Change goal</a></p>
+    `)
+	}
+	io.WriteString(w, `
+  </div>
+
   <div class="card books-per-time">
     <h2>Per Year</h2>
 
+    <div class="chart">`)
+	BooksPerYearChart(booksPerYear).WriteSVG(w)
+	io.WriteString(w, `</div>
+
     <table>
       `)
 	for _, bpt := range booksPerYear {
 		io.WriteString(w, `
         <tr>
-          <th>`)
+          <th><a href="`)
+		io.WriteString(w, html.EscapeString(route.UserHomeYearPath(bva.PathUser.Username, bpt.Time.Year())))
+		io.WriteString(w, `">`)
 		io.WriteString(w, html.EscapeString(bpt.Time.Format("2006")))
-		io.WriteString(w, `</th>
+		io.WriteString(w, `</a></th>
           <td>`)
 		io.WriteString(w, strconv.FormatInt(int64(bpt.Count), 10))
 		io.WriteString(w, `</td>
@@ -139,16 +302,74 @@ func UserHome(
     </table>
   </div>
 
+  `)
+	if len(pagesPerYear) > 0 {
+		io.WriteString(w, `
+    <div class="card books-per-time">
+      <h2>Pages Per Year</h2>
+
+      <table>
+        `)
+		for _, ppt := range pagesPerYear {
+			io.WriteString(w, `
+          <tr>
+            <th>`)
+			io.WriteString(w, html.EscapeString(ppt.Time.Format("2006")))
+			io.WriteString(w, `</th>
+            <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(ppt.Pages), 10))
+			io.WriteString(w, `</td>
+          </tr>
+        `)
+		}
+		io.WriteString(w, `
+      </table>
+    </div>
+  `)
+	}
+	io.WriteString(w, `
+
+  `)
+	if len(hoursListenedPerYear) > 0 {
+		io.WriteString(w, `
+    <div class="card books-per-time">
+      <h2>Hours Listened Per Year</h2>
+
+      <table>
+        `)
+		for _, hpt := range hoursListenedPerYear {
+			io.WriteString(w, `
+          <tr>
+            <th>`)
+			io.WriteString(w, html.EscapeString(hpt.Time.Format("2006")))
+			io.WriteString(w, `</th>
+            <td>`)
+			io.WriteString(w, html.EscapeString(strconv.FormatFloat(hpt.Hours, 'f', 1, 64)))
+			io.WriteString(w, `</td>
+          </tr>
+        `)
+		}
+		io.WriteString(w, `
+      </table>
+    </div>
+  `)
+	}
+	io.WriteString(w, `
+
   <div class="card books-per-time">
-    <h2>Last Year Per Month</h2>
+    <h2>Per Month</h2>
+
+    <div class="chart">`)
+	BooksPerMonthChart(booksPerMonth).WriteSVG(w)
+	io.WriteString(w, `</div>
 
     <table>
       `)
-	for _, bpt := range booksPerMonthForLastYear {
+	for _, bpt := range booksPerMonth {
 		io.WriteString(w, `
         <tr>
           <th>`)
-		io.WriteString(w, html.EscapeString(bpt.Time.Format("January")))
+		io.WriteString(w, html.EscapeString(bpt.Time.Format("January 2006")))
 		io.WriteString(w, `</th>
           <td>`)
 		io.WriteString(w, strconv.FormatInt(int64(bpt.Count), 10))
@@ -158,7 +379,85 @@ func UserHome(
 	}
 	io.WriteString(w, `
     </table>
+
+    `)
+	if averageDaysPerBook > 0 {
+		io.WriteString(w, `
+      <p class="average-days">Average of `)
+		io.WriteString(w, html.EscapeString(strconv.FormatFloat(averageDaysPerBook, 'f', 1, 64)))
+		io.WriteString(w, ` days per book</p>
+    `)
+	}
+	io.WriteString(w, `
   </div>
+
+  `)
+	if readingRecords.BestYear.Count > 0 {
+		io.WriteString(w, `
+    <div class="card records">
+      <h2>Records</h2>
+
+      <dl>
+        <dt>Longest streak</dt>
+        <dd>`)
+		io.WriteString(w, strconv.FormatInt(int64(readingRecords.LongestMonthStreak), 10))
+		io.WriteString(w, ` months, `)
+		io.WriteString(w, strconv.FormatInt(int64(readingRecords.LongestWeekStreak), 10))
+		io.WriteString(w, ` weeks</dd>
+        <dt>Current streak</dt>
+        <dd>`)
+		io.WriteString(w, strconv.FormatInt(int64(readingRecords.CurrentMonthStreak), 10))
+		io.WriteString(w, ` months, `)
+		io.WriteString(w, strconv.FormatInt(int64(readingRecords.CurrentWeekStreak), 10))
+		io.WriteString(w, ` weeks</dd>
+        <dt>Best month</dt>
+        <dd>`)
+		io.WriteString(w, html.EscapeString(readingRecords.BestMonth.Time.Format("January 2006")))
+		io.WriteString(w, ` with `)
+		io.WriteString(w, strconv.FormatInt(int64(readingRecords.BestMonth.Count), 10))
+		io.WriteString(w, ` books</dd>
+        <dt>Best year</dt>
+        <dd>`)
+		io.WriteString(w, html.EscapeString(readingRecords.BestYear.Time.Format("2006")))
+		io.WriteString(w, ` with `)
+		io.WriteString(w, strconv.FormatInt(int64(readingRecords.BestYear.Count), 10))
+		io.WriteString(w, ` books</dd>
+        `)
+		if readingRecords.FastestRead != nil {
+			io.WriteString(w, `
+          <dt>Fastest read</dt>
+          <dd>
+            <a href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, readingRecords.FastestRead.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(readingRecords.FastestRead.Title))
+			io.WriteString(w, `</a>
+            in `)
+			io.WriteString(w, strconv.FormatInt(int64(readingRecords.FastestRead.ReadingDays()), 10))
+			io.WriteString(w, ` days
+          </dd>
+        `)
+		}
+		io.WriteString(w, `
+      </dl>
+    </div>
+  `)
+	}
+	io.WriteString(w, `
+
+  `)
+	if len(booksPerFormat) > 0 {
+		io.WriteString(w, `
+    <div class="card books-per-time">
+      <h2>Per Format</h2>
+
+      <div class="chart">`)
+		BooksPerFormatChart(booksPerFormat).WriteSVG(w)
+		io.WriteString(w, `</div>
+    </div>
+  `)
+	}
+	io.WriteString(w, `
 </div>
 
 <div class="card">
@@ -167,9 +466,11 @@ func UserHome(
 		io.WriteString(w, `
     <ol class="years">
       <li>
-        <h2>`)
+        <h2><a href="`)
+		io.WriteString(w, html.EscapeString(route.YearReviewPath(bva.PathUser.Username, ybl.Year)))
+		io.WriteString(w, `">`)
 		io.WriteString(w, strconv.FormatInt(int64(ybl.Year), 10))
-		io.WriteString(w, `</h2>
+		io.WriteString(w, `</a></h2>
         <ol class="books">
           `)
 		for _, book := range ybl.Books {
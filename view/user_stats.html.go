@@ -0,0 +1,264 @@
+package view
+
+import (
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func UserStats(
+	w io.Writer,
+	bva *BaseViewArgs,
+	topAuthors []data.AuthorCountItem,
+	formatsPerYear []data.FormatsPerTimeItem,
+	locationFrequency []data.LocationCountItem,
+	booksPerMonthOfYear []data.MonthCountItem,
+	readingsPerYear []data.ReadingsPerTimeItem,
+) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  .stats {
+    display: grid;
+  }
+
+  .stats h2 {
+    margin: 0 0 1rem 0;
+  }
+
+  .stats table {
+    border-collapse: collapse;
+  }
+
+  .stats th {
+    font-weight: bold;
+    color: var(--light-text-color);
+    padding: 2px 1rem 2px 0;
+    text-align: left;
+  }
+
+  .stats td {
+    color: var(--light-text-color);
+    text-align: right;
+    padding: 2px 0 2px 1rem;
+  }
+
+  .stats thead th {
+    text-align: right;
+  }
+
+  .stats .empty {
+    color: var(--light-text-color);
+  }
+
+@media (max-width: 32rem) {
+  .stats {
+    grid-template-columns: 1fr;
+  }
+}
+
+@media not all and (max-width: 32rem) {
+  .stats {
+    grid-template-columns: 1fr 1fr;
+  }
+}
+</style>
+
+<div class="stats">
+  <div class="card">
+    <h2>Top Authors</h2>
+
+    `)
+	if len(topAuthors) == 0 {
+		io.WriteString(w, `
+      <p class="empty">No finished books</p>
+    `)
+	} else {
+		io.WriteString(w, `
+      <table>
+        `)
+		for _, a := range topAuthors {
+			io.WriteString(w, `
+          <tr>
+            `)
+			if a.AuthorID == 0 {
+				io.WriteString(w, `
+              <th>`)
+				io.WriteString(w, html.EscapeString(a.Author))
+				io.WriteString(w, `</th>
+            `)
+			} else {
+				io.WriteString(w, `
+              <th><a href="`)
+				io.WriteString(w, html.EscapeString(route.AuthorPath(bva.PathUser.Username, a.AuthorID)))
+				io.WriteString(w, `">`)
+				io.WriteString(w, html.EscapeString(a.Author))
+				io.WriteString(w, `</a></th>
+            `)
+			}
+			io.WriteString(w, `
+            <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(a.Count), 10))
+			io.WriteString(w, `</td>
+          </tr>
+        `)
+		}
+		io.WriteString(w, `
+      </table>
+    `)
+	}
+	io.WriteString(w, `
+  </div>
+
+  <div class="card">
+    <h2>Formats Per Year</h2>
+
+    `)
+	if len(formatsPerYear) == 0 {
+		io.WriteString(w, `
+      <p class="empty">No finished books</p>
+    `)
+	} else {
+		io.WriteString(w, `
+      <table>
+        <thead>
+          <tr>
+            <th></th>
+            <th title="Text">📖</th>
+            <th title="Audio">🎧</th>
+            <th title="Video">📺</th>
+          </tr>
+        </thead>
+        <tbody>
+          `)
+		for _, fpt := range formatsPerYear {
+			io.WriteString(w, `
+            <tr>
+              <th>`)
+			io.WriteString(w, html.EscapeString(fpt.Time.Format("2006")))
+			io.WriteString(w, `</th>
+              <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(fpt.Text), 10))
+			io.WriteString(w, `</td>
+              <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(fpt.Audio), 10))
+			io.WriteString(w, `</td>
+              <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(fpt.Video), 10))
+			io.WriteString(w, `</td>
+            </tr>
+          `)
+		}
+		io.WriteString(w, `
+        </tbody>
+      </table>
+    `)
+	}
+	io.WriteString(w, `
+  </div>
+
+  <div class="card">
+    <h2>Rereads Per Year</h2>
+
+    `)
+	if len(readingsPerYear) == 0 {
+		io.WriteString(w, `
+      <p class="empty">No finished books</p>
+    `)
+	} else {
+		io.WriteString(w, `
+      <table>
+        <thead>
+          <tr>
+            <th></th>
+            <th>Readings</th>
+            <th>Rereads</th>
+          </tr>
+        </thead>
+        <tbody>
+          `)
+		for _, rpt := range readingsPerYear {
+			io.WriteString(w, `
+            <tr>
+              <th>`)
+			io.WriteString(w, html.EscapeString(rpt.Time.Format("2006")))
+			io.WriteString(w, `</th>
+              <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(rpt.Readings), 10))
+			io.WriteString(w, `</td>
+              <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(rpt.Rereads), 10))
+			io.WriteString(w, `</td>
+            </tr>
+          `)
+		}
+		io.WriteString(w, `
+        </tbody>
+      </table>
+    `)
+	}
+	io.WriteString(w, `
+  </div>
+
+  <div class="card">
+    <h2>Locations</h2>
+
+    `)
+	if len(locationFrequency) == 0 {
+		io.WriteString(w, `
+      <p class="empty">No books with a location</p>
+    `)
+	} else {
+		io.WriteString(w, `
+      <table>
+        `)
+		for _, l := range locationFrequency {
+			io.WriteString(w, `
+          <tr>
+            <th>`)
+			io.WriteString(w, html.EscapeString(l.Location))
+			io.WriteString(w, `</th>
+            <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(l.Count), 10))
+			io.WriteString(w, `</td>
+          </tr>
+        `)
+		}
+		io.WriteString(w, `
+      </table>
+    `)
+	}
+	io.WriteString(w, `
+  </div>
+
+  <div class="card">
+    <h2>Most Read Months</h2>
+
+    <table>
+      `)
+	for _, m := range booksPerMonthOfYear {
+		io.WriteString(w, `
+        <tr>
+          <th>`)
+		io.WriteString(w, html.EscapeString(m.Month.String()))
+		io.WriteString(w, `</th>
+          <td>`)
+		io.WriteString(w, strconv.FormatInt(int64(m.Count), 10))
+		io.WriteString(w, `</td>
+        </tr>
+      `)
+	}
+	io.WriteString(w, `
+    </table>
+  </div>
+</div>
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}
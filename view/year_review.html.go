@@ -0,0 +1,262 @@
+package view
+
+import (
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jackc/booklog/data"
+	"github.com/jackc/booklog/route"
+)
+
+func YearReview(w io.Writer, bva *BaseViewArgs, review *data.YearReview, yearBookList *YearBookList) error {
+	LayoutHeader(w, bva)
+	io.WriteString(w, `
+<style>
+  .review {
+    display: grid;
+  }
+
+  .review h2 {
+    margin: 0 0 1rem 0;
+  }
+
+  .review dt, .review th, .review td, .review .empty {
+    color: var(--light-text-color);
+  }
+
+  .review dd {
+    margin: 0 0 0.5rem 0;
+  }
+
+  .review table {
+    border-collapse: collapse;
+  }
+
+  .review th {
+    text-align: left;
+    padding: 2px 1rem 2px 0;
+  }
+
+  .review td {
+    text-align: right;
+    padding: 2px 0;
+  }
+
+  .review .total {
+    font-size: 3rem;
+    margin: 0;
+  }
+
+  .chart svg {
+    max-width: 100%;
+    height: auto;
+  }
+
+  nav.years {
+    display: flex;
+    justify-content: space-between;
+  }
+
+  ol.books {
+    list-style: none;
+    margin: 0;
+    padding: 0;
+  }
+
+  ol.books > li {
+    margin: 0.5rem 0;
+  }
+
+  ol.books .author, ol.books time {
+    color: var(--light-text-color);
+  }
+
+@media (max-width: 32rem) {
+  .review {
+    grid-template-columns: 1fr;
+  }
+}
+
+@media not all and (max-width: 32rem) {
+  .review {
+    grid-template-columns: 1fr 1fr;
+  }
+}
+</style>
+
+<div class="card">
+  <header>`)
+	io.WriteString(w, strconv.FormatInt(int64(review.Year), 10))
+	io.WriteString(w, ` in Review</header>
+  <nav class="years">
+    <a href="`)
+	io.WriteString(w, html.EscapeString(route.YearReviewPath(bva.PathUser.Username, review.Year-1)))
+	io.WriteString(w, `">← `)
+	io.WriteString(w, strconv.FormatInt(int64(review.Year-1), 10))
+	io.WriteString(w, `</a>
+    <a href="`)
+	io.WriteString(w, html.EscapeString(route.YearReviewPath(bva.PathUser.Username, review.Year+1)))
+	io.WriteString(w, `">`)
+	io.WriteString(w, strconv.FormatInt(int64(review.Year+1), 10))
+	io.WriteString(w, ` →</a>
+  </nav>
+</div>
+
+`)
+	if review.BookCount == 0 {
+		io.WriteString(w, `
+  <div class="card">
+    <p>No books finished in `)
+		io.WriteString(w, strconv.FormatInt(int64(review.Year), 10))
+		io.WriteString(w, `.</p>
+  </div>
+`)
+	} else {
+		io.WriteString(w, `
+  <div class="review">
+    <div class="card">
+      <h2>Books</h2>
+      <p class="total">`)
+		io.WriteString(w, strconv.FormatInt(int64(review.BookCount), 10))
+		io.WriteString(w, `</p>
+      <table>
+        `)
+		for _, f := range review.Formats {
+			io.WriteString(w, `
+          <tr>
+            <th>`)
+			io.WriteString(w, html.EscapeString(f.Format))
+			io.WriteString(w, `</th>
+            <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(f.Count), 10))
+			io.WriteString(w, `</td>
+          </tr>
+        `)
+		}
+		io.WriteString(w, `
+      </table>
+    </div>
+
+    <div class="card">
+      <h2>Top Authors</h2>
+      <table>
+        `)
+		for _, a := range review.TopAuthors {
+			io.WriteString(w, `
+          <tr>
+            `)
+			if a.AuthorID == 0 {
+				io.WriteString(w, `
+              <th>`)
+				io.WriteString(w, html.EscapeString(a.Author))
+				io.WriteString(w, `</th>
+            `)
+			} else {
+				io.WriteString(w, `
+              <th><a href="`)
+				io.WriteString(w, html.EscapeString(route.AuthorPath(bva.PathUser.Username, a.AuthorID)))
+				io.WriteString(w, `">`)
+				io.WriteString(w, html.EscapeString(a.Author))
+				io.WriteString(w, `</a></th>
+            `)
+			}
+			io.WriteString(w, `
+            <td>`)
+			io.WriteString(w, strconv.FormatInt(int64(a.Count), 10))
+			io.WriteString(w, `</td>
+          </tr>
+        `)
+		}
+		io.WriteString(w, `
+      </table>
+    </div>
+
+    <div class="card">
+      <h2>Highlights</h2>
+      <dl>
+        <dt>First book</dt>
+        <dd><a href="`)
+		io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, review.FirstBook.ID)))
+		io.WriteString(w, `">`)
+		io.WriteString(w, html.EscapeString(review.FirstBook.Title))
+		io.WriteString(w, `</a> on `)
+		io.WriteString(w, html.EscapeString(review.FirstBook.FinishDate.Format("January 2")))
+		io.WriteString(w, `</dd>
+        <dt>Last book</dt>
+        <dd><a href="`)
+		io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, review.LastBook.ID)))
+		io.WriteString(w, `">`)
+		io.WriteString(w, html.EscapeString(review.LastBook.Title))
+		io.WriteString(w, `</a> on `)
+		io.WriteString(w, html.EscapeString(review.LastBook.FinishDate.Format("January 2")))
+		io.WriteString(w, `</dd>
+        `)
+		if review.LongestBook != nil {
+			io.WriteString(w, `
+          <dt>Longest book</dt>
+          <dd><a href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, review.LongestBook.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(review.LongestBook.Title))
+			io.WriteString(w, `</a> with `)
+			io.WriteString(w, strconv.FormatInt(int64(review.LongestBook.PageCount), 10))
+			io.WriteString(w, ` pages</dd>
+          <dt>Shortest book</dt>
+          <dd><a href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, review.ShortestBook.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(review.ShortestBook.Title))
+			io.WriteString(w, `</a> with `)
+			io.WriteString(w, strconv.FormatInt(int64(review.ShortestBook.PageCount), 10))
+			io.WriteString(w, ` pages</dd>
+        `)
+		}
+		io.WriteString(w, `
+      </dl>
+    </div>
+
+    <div class="card">
+      <h2>Per Month</h2>
+      <div class="chart">`)
+		MonthCountChart(review.BooksPerMonth).WriteSVG(w)
+		io.WriteString(w, `</div>
+    </div>
+  </div>
+
+  <div class="card">
+    <h2>All Books</h2>
+    <ol class="books">
+      `)
+		for _, book := range yearBookList.Books {
+			io.WriteString(w, `
+        <li>
+          <time datetime="`)
+			io.WriteString(w, html.EscapeString(book.FinishDate.Format("2006-01-02")))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(book.FinishDate.Format("January 2")))
+			io.WriteString(w, `</time>
+          <a href="`)
+			io.WriteString(w, html.EscapeString(route.BookPath(bva.PathUser.Username, book.ID)))
+			io.WriteString(w, `">`)
+			io.WriteString(w, html.EscapeString(book.Title))
+			io.WriteString(w, `</a>
+          <span class="author">`)
+			io.WriteString(w, html.EscapeString(book.Author))
+			io.WriteString(w, `</span>
+        </li>
+      `)
+		}
+		io.WriteString(w, `
+    </ol>
+  </div>
+`)
+	}
+	io.WriteString(w, `
+`)
+	LayoutFooter(w, bva)
+	io.WriteString(w, `
+`)
+
+	return nil
+}